@@ -0,0 +1,165 @@
+package zetasqlite
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// Array returns sql.Scanner to scan a value of ARRAY type into dest.
+// zetasqlite returns []interface{} values by default for array values because the element can be NULL,
+// so Array can be used to scan them into typed slices ( e.g. rows.Scan(zetasqlite.Array(&ids)) ).
+// dest must be a pointer to slice such as *[]int64, *[]string, *[][]byte or *[][]float64.
+// Numeric elements can be scanned into other numeric types only if the value is representable by the type,
+// so FLOAT64 value with the fractional part or INT64 value that overflows the type returns an error.
+// STRUCT elements are scanned into map[string]interface{} keyed by the field name,
+// and NULL elements are scanned only into the slice of pointers or interface{} values.
+func Array(dest interface{}) sql.Scanner {
+	return &arrayScanner{dest: dest}
+}
+
+type arrayScanner struct {
+	dest interface{}
+}
+
+// Scan implements sql.Scanner interface.
+func (s *arrayScanner) Scan(src interface{}) error {
+	rv := reflect.ValueOf(s.dest)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("cannot scan array value into %T: destination must be a pointer to slice", s.dest)
+	}
+	return assignArrayValue(rv.Elem(), src)
+}
+
+func assignArrayValue(dst reflect.Value, src interface{}) error {
+	if src == nil {
+		switch dst.Kind() {
+		case reflect.Ptr, reflect.Interface, reflect.Slice, reflect.Map:
+			dst.Set(reflect.Zero(dst.Type()))
+			return nil
+		}
+		return fmt.Errorf("cannot scan NULL into %s", dst.Type())
+	}
+	switch dst.Kind() {
+	case reflect.Ptr:
+		v := reflect.New(dst.Type().Elem())
+		if err := assignArrayValue(v.Elem(), src); err != nil {
+			return err
+		}
+		dst.Set(v)
+		return nil
+	case reflect.Interface:
+		dst.Set(reflect.ValueOf(src))
+		return nil
+	case reflect.Slice:
+		if b, ok := src.([]byte); ok && dst.Type().Elem().Kind() == reflect.Uint8 {
+			dst.Set(reflect.ValueOf(append([]byte{}, b...)).Convert(dst.Type()))
+			return nil
+		}
+		elems, ok := src.([]interface{})
+		if !ok {
+			return fmt.Errorf("cannot scan %T value into %s", src, dst.Type())
+		}
+		slice := reflect.MakeSlice(dst.Type(), len(elems), len(elems))
+		for idx, elem := range elems {
+			if err := assignArrayValue(slice.Index(idx), elem); err != nil {
+				return fmt.Errorf("failed to scan array element at %d: %w", idx, err)
+			}
+		}
+		dst.Set(slice)
+		return nil
+	case reflect.Map:
+		// STRUCT value is represented as the list of single field maps to keep the field order.
+		fields, ok := src.([]map[string]interface{})
+		if !ok || dst.Type().Key().Kind() != reflect.String {
+			return fmt.Errorf("cannot scan %T value into %s", src, dst.Type())
+		}
+		m := reflect.MakeMapWithSize(dst.Type(), len(fields))
+		for _, field := range fields {
+			for name, value := range field {
+				v := reflect.New(dst.Type().Elem()).Elem()
+				if err := assignArrayValue(v, value); err != nil {
+					return fmt.Errorf("failed to scan struct field %s: %w", name, err)
+				}
+				m.SetMapIndex(reflect.ValueOf(name).Convert(dst.Type().Key()), v)
+			}
+		}
+		dst.Set(m)
+		return nil
+	}
+	sv := reflect.ValueOf(src)
+	if isNumberKind(sv.Kind()) && isNumberKind(dst.Kind()) {
+		return assignNumberValue(dst, sv)
+	}
+	if sv.Kind() == dst.Kind() {
+		dst.Set(sv.Convert(dst.Type()))
+		return nil
+	}
+	return fmt.Errorf("cannot scan %T value into %s", src, dst.Type())
+}
+
+// assignNumberValue converts the number src to the type of dst.
+// It returns an error instead of truncating or wrapping around the value that is not representable by dst.
+func assignNumberValue(dst, src reflect.Value) error {
+	var overflow bool
+	switch src.Kind() {
+	case reflect.Float32, reflect.Float64:
+		f := src.Float()
+		switch {
+		case isFloatKind(dst.Kind()):
+			overflow = dst.OverflowFloat(f)
+		case f != math.Trunc(f) || math.IsInf(f, 0) || math.IsNaN(f):
+			return fmt.Errorf("cannot scan %v into %s: value has the fractional part", f, dst.Type())
+		case isIntKind(dst.Kind()):
+			overflow = f < math.MinInt64 || f >= math.MaxInt64 || dst.OverflowInt(int64(f))
+		default:
+			overflow = f < 0 || f >= math.MaxUint64 || dst.OverflowUint(uint64(f))
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i := src.Int()
+		switch {
+		case isFloatKind(dst.Kind()):
+		case isIntKind(dst.Kind()):
+			overflow = dst.OverflowInt(i)
+		default:
+			overflow = i < 0 || dst.OverflowUint(uint64(i))
+		}
+	default:
+		u := src.Uint()
+		switch {
+		case isFloatKind(dst.Kind()):
+		case isIntKind(dst.Kind()):
+			overflow = u > math.MaxInt64 || dst.OverflowInt(int64(u))
+		default:
+			overflow = dst.OverflowUint(u)
+		}
+	}
+	if overflow {
+		return fmt.Errorf("cannot scan %v into %s: value is out of range", src.Interface(), dst.Type())
+	}
+	dst.Set(src.Convert(dst.Type()))
+	return nil
+}
+
+func isNumberKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+func isIntKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true
+	}
+	return false
+}
+
+func isFloatKind(kind reflect.Kind) bool {
+	return kind == reflect.Float32 || kind == reflect.Float64
+}
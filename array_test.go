@@ -0,0 +1,112 @@
+package zetasqlite_test
+
+import (
+	"database/sql"
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	zetasqlite "github.com/goccy/go-zetasqlite"
+)
+
+func TestArray(t *testing.T) {
+	db, err := sql.Open("zetasqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	t.Run("scan", func(t *testing.T) {
+		var (
+			ints    []int64
+			strs    []string
+			structs []map[string]interface{}
+		)
+		if err := db.QueryRow(
+			`SELECT [1, 2, 3], ['a', 'b'], [STRUCT(1 AS id, 'x' AS name), STRUCT(2, 'y')]`,
+		).Scan(zetasqlite.Array(&ints), zetasqlite.Array(&strs), zetasqlite.Array(&structs)); err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]int64{1, 2, 3}, ints); diff != "" {
+			t.Errorf("(-want +got):\n%s", diff)
+		}
+		if diff := cmp.Diff([]string{"a", "b"}, strs); diff != "" {
+			t.Errorf("(-want +got):\n%s", diff)
+		}
+		if len(structs) != 2 || structs[0]["name"] != "x" || structs[1]["name"] != "y" {
+			t.Errorf("unexpected structs %v", structs)
+		}
+	})
+	t.Run("scan null", func(t *testing.T) {
+		var (
+			ints   []*int64
+			floats []float64
+		)
+		if err := db.QueryRow(`SELECT [1, NULL], CAST(NULL AS ARRAY<FLOAT64>)`).Scan(
+			zetasqlite.Array(&ints),
+			zetasqlite.Array(&floats),
+		); err != nil {
+			t.Fatal(err)
+		}
+		if len(ints) != 2 || *ints[0] != 1 || ints[1] != nil {
+			t.Errorf("unexpected ints %v", ints)
+		}
+		if floats != nil {
+			t.Errorf("expected nil but got %v", floats)
+		}
+		var strs []string
+		if err := db.QueryRow(`SELECT ['a', NULL]`).Scan(zetasqlite.Array(&strs)); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+	t.Run("nested", func(t *testing.T) {
+		var nested [][]int64
+		if err := zetasqlite.Array(&nested).Scan([]interface{}{
+			[]interface{}{int64(1), int64(2)},
+			[]interface{}{},
+			[]interface{}{int64(3)},
+		}); err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([][]int64{{1, 2}, {}, {3}}, nested); diff != "" {
+			t.Errorf("(-want +got):\n%s", diff)
+		}
+	})
+	t.Run("bytes", func(t *testing.T) {
+		var bytes [][]byte
+		if err := db.QueryRow(`SELECT [b'abc', b'']`).Scan(zetasqlite.Array(&bytes)); err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([][]byte{[]byte("abc"), {}}, bytes); diff != "" {
+			t.Errorf("(-want +got):\n%s", diff)
+		}
+	})
+	t.Run("number conversion", func(t *testing.T) {
+		var (
+			floats []float64
+			ints   []int32
+		)
+		if err := zetasqlite.Array(&floats).Scan([]interface{}{int64(1), float64(1.5)}); err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]float64{1, 1.5}, floats); diff != "" {
+			t.Errorf("(-want +got):\n%s", diff)
+		}
+		if err := zetasqlite.Array(&ints).Scan([]interface{}{int64(1), float64(2)}); err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]int32{1, 2}, ints); diff != "" {
+			t.Errorf("(-want +got):\n%s", diff)
+		}
+		for _, src := range []interface{}{float64(1.5), int64(math.MaxInt32 + 1), float64(math.MaxInt64)} {
+			if err := zetasqlite.Array(&ints).Scan([]interface{}{src}); err == nil {
+				t.Errorf("expected error for %v", src)
+			}
+		}
+		var uints []uint64
+		if err := zetasqlite.Array(&uints).Scan([]interface{}{int64(-1)}); err == nil {
+			t.Error("expected error for negative value")
+		}
+	})
+}
@@ -18,3 +18,25 @@ func WithCurrentTime(ctx context.Context, now time.Time) context.Context {
 func CurrentTime(ctx context.Context) *time.Time {
 	return internal.CurrentTime(ctx)
 }
+
+// WithDefaultProject specifies the project used to resolve table and function names which are not qualified by project.
+// For example, `dataset.table` is resolved as `project.dataset.table` in the query executed with the returned context.
+// It overrides the first element of the name path set by SetNamePath.
+func WithDefaultProject(ctx context.Context, project string) context.Context {
+	return internal.WithDefaultProject(ctx, project)
+}
+
+// WithDefaultDataset specifies the dataset used to resolve table and function names which are not qualified by dataset.
+// For example, `table` is resolved as `project.dataset.table` in the query executed with the context
+// returned by WithDefaultProject and WithDefaultDataset.
+// It overrides the second element of the name path set by SetNamePath.
+func WithDefaultDataset(ctx context.Context, dataset string) context.Context {
+	return internal.WithDefaultDataset(ctx, dataset)
+}
+
+// WithRandSeed specifies the seed used to sample rows by TABLESAMPLE.
+// Queries executed with the returned context sample the same rows as long as the table is not changed.
+// The seed specified by REPEATABLE clause takes precedence over it.
+func WithRandSeed(ctx context.Context, seed int64) context.Context {
+	return internal.WithRandSeed(ctx, seed)
+}
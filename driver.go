@@ -104,6 +104,13 @@ func (c *ZetaSQLiteConn) SetExplainMode(enabled bool) {
 	c.analyzer.SetExplainMode(enabled)
 }
 
+// SetRatNumericMode makes NUMERIC and BIGNUMERIC values scanned as *big.Rat to keep their exact values.
+// By default, they are scanned as decimal strings without exponent and trailing zeros ( e.g. "1.5", "-0.001" ),
+// which can also be received by the type implementing sql.Scanner.
+func (c *ZetaSQLiteConn) SetRatNumericMode(enabled bool) {
+	c.analyzer.SetRatNumericMode(enabled)
+}
+
 // SetMaxNamePath specifies the maximum value of name path.
 // If the name path in the query is the maximum value, the name path set as prefix is not used.
 // Effective only when a value greater than zero is specified ( default zero ).
@@ -143,6 +150,7 @@ func (c *ZetaSQLiteConn) Prepare(query string) (driver.Stmt, error) {
 }
 
 func (c *ZetaSQLiteConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	ctx = internal.WithPreparedStmt(ctx)
 	conn := internal.NewConn(c.conn, c.tx)
 	actionFuncs, err := c.analyzer.Analyze(ctx, conn, query, nil)
 	if err != nil {
@@ -183,6 +191,10 @@ func (c *ZetaSQLiteConn) ExecContext(ctx context.Context, query string, args []d
 
 	var result driver.Result
 	for _, actionFunc := range actionFuncs {
+		// don't run the remaining statements of the script after the context is canceled.
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		action, err := actionFunc()
 		if err != nil {
 			return nil, err
@@ -217,6 +229,9 @@ func (c *ZetaSQLiteConn) QueryContext(ctx context.Context, query string, args []
 		}
 	}()
 	for _, actionFunc := range actionFuncs {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		action, err := actionFunc()
 		if err != nil {
 			return nil, err
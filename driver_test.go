@@ -3,6 +3,9 @@ package zetasqlite_test
 import (
 	"context"
 	"database/sql"
+	"math/big"
+	"path/filepath"
+	"reflect"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -91,6 +94,71 @@ func TestRegisterCustomDriver(t *testing.T) {
 	}
 }
 
+func TestDefaultProjectAndDataset(t *testing.T) {
+	db, err := sql.Open("zetasqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	ctx := zetasqlite.WithDefaultProject(context.Background(), "prj")
+	ctx = zetasqlite.WithDefaultDataset(ctx, "broadridge")
+	if _, err := db.ExecContext(ctx, `CREATE TABLE accounts (id INT64, name STRING)`); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.ExecContext(ctx, "INSERT `prj.broadridge.accounts` (id, name) VALUES (1, 'alice')"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.ExecContext(ctx, `INSERT broadridge.accounts (id, name) VALUES (2, 'bob')`); err != nil {
+		t.Fatal(err)
+	}
+	for _, table := range []string{"accounts", "broadridge.accounts", "prj.broadridge.accounts"} {
+		var count int64
+		if err := db.QueryRowContext(ctx, "SELECT COUNT(*) FROM "+table).Scan(&count); err != nil {
+			t.Fatalf("%s: %v", table, err)
+		}
+		if count != 2 {
+			t.Fatalf("%s: expected 2 rows but got %d", table, count)
+		}
+	}
+	// the default project and dataset are applied only to the queries executed with the context.
+	var count int64
+	if err := db.QueryRow("SELECT COUNT(*) FROM `prj.broadridge.accounts`").Scan(&count); err != nil {
+		t.Fatal(err)
+	}
+	if count != 2 {
+		t.Fatalf("expected 2 rows but got %d", count)
+	}
+}
+
+func TestRatNumericMode(t *testing.T) {
+	sql.Register("zetasqlite-rat-numeric", &zetasqlite.ZetaSQLiteDriver{
+		ConnectHook: func(conn *zetasqlite.ZetaSQLiteConn) error {
+			conn.SetRatNumericMode(true)
+			return nil
+		},
+	})
+	db, err := sql.Open("zetasqlite-rat-numeric", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	var (
+		numeric    *big.Rat
+		bignumeric *big.Rat
+	)
+	if err := db.QueryRow(
+		`SELECT SUM(x), BIGNUMERIC '0.00000000000000000000000000000000000001' FROM UNNEST([NUMERIC '0.1', NUMERIC '0.2']) AS x`,
+	).Scan(&numeric, &bignumeric); err != nil {
+		t.Fatal(err)
+	}
+	if numeric.Cmp(big.NewRat(3, 10)) != 0 {
+		t.Errorf("expected 0.3 but got %s", numeric.FloatString(9))
+	}
+	if expected, _ := new(big.Rat).SetString("1e-38"); bignumeric.Cmp(expected) != 0 {
+		t.Errorf("expected 1e-38 but got %s", bignumeric.RatString())
+	}
+}
+
 func TestChangedCatalog(t *testing.T) {
 	t.Run("table", func(t *testing.T) {
 		db, err := sql.Open("zetasqlite", ":memory:")
@@ -186,6 +254,41 @@ CREATE TABLE IF NOT EXISTS Singers (
 	})
 }
 
+func TestPersistentCatalog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "catalog.db")
+	db, err := sql.Open("zetasqlite", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec(`
+CREATE TABLE persistent_table (id INT64, name STRING);
+CREATE FUNCTION persistent_func(x INT64) AS (x * 10);
+INSERT persistent_table (id, name) VALUES (1, 'alice');
+`); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// open the same file by another name so that the catalog is loaded from the file instead of the memory.
+	reopened, err := sql.Open("zetasqlite", "file:"+path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reopened.Close()
+	var (
+		id   int64
+		name string
+	)
+	if err := reopened.QueryRow(`SELECT persistent_func(id), name FROM persistent_table`).Scan(&id, &name); err != nil {
+		t.Fatal(err)
+	}
+	if id != 10 || name != "alice" {
+		t.Fatalf("unexpected row (%d, %s)", id, name)
+	}
+}
+
 func TestPreparedStatements(t *testing.T) {
 	t.Run("prepared select", func(t *testing.T) {
 		db, err := sql.Open("zetasqlite", ":memory:")
@@ -262,3 +365,82 @@ CREATE TABLE IF NOT EXISTS Singers (
 		}
 	})
 }
+
+func TestStructColumnTypeName(t *testing.T) {
+	db, err := sql.Open("zetasqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	rows, err := db.Query("SELECT STRUCT(1), STRUCT(1 AS a), [STRUCT(1 AS a, 'x')]")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	type typeName struct {
+		Name       string
+		FormatType string
+	}
+	var typeNames []*typeName
+	for _, columnType := range columnTypes {
+		typ, err := zetasqlite.UnmarshalDatabaseTypeName(columnType.DatabaseTypeName())
+		if err != nil {
+			t.Fatal(err)
+		}
+		typeNames = append(typeNames, &typeName{
+			Name:       typ.Name,
+			FormatType: typ.FormatType(),
+		})
+	}
+	if diff := cmp.Diff([]*typeName{
+		{Name: "STRUCT<INT64>", FormatType: "STRUCT<INT64>"},
+		{Name: "STRUCT<a INT64>", FormatType: "STRUCT<`a` INT64>"},
+		{Name: "ARRAY<STRUCT<a INT64, STRING>>", FormatType: "ARRAY<STRUCT<`a` INT64,STRING>>"},
+	}, typeNames); diff != "" {
+		t.Errorf("(-want +got):\n%s", diff)
+	}
+}
+
+func TestColumnTypes(t *testing.T) {
+	db, err := sql.Open("zetasqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	rows, err := db.Query("SELECT 1, [1, 2], STRUCT(1 AS a), NUMERIC '1.5', JSON '{}', 1.5, TRUE")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	type columnType struct {
+		Name     string
+		ScanType reflect.Type
+	}
+	var got []*columnType
+	for _, ct := range columnTypes {
+		typ, err := zetasqlite.UnmarshalDatabaseTypeName(ct.DatabaseTypeName())
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, &columnType{Name: typ.Name, ScanType: ct.ScanType()})
+	}
+	if diff := cmp.Diff([]*columnType{
+		{Name: "INT64", ScanType: reflect.TypeOf(int64(0))},
+		{Name: "ARRAY<INT64>", ScanType: reflect.TypeOf([]interface{}{})},
+		{Name: "STRUCT<a INT64>", ScanType: reflect.TypeOf([]map[string]interface{}{})},
+		{Name: "NUMERIC", ScanType: reflect.TypeOf("")},
+		{Name: "JSON", ScanType: reflect.TypeOf("")},
+		{Name: "DOUBLE", ScanType: reflect.TypeOf(float64(0))},
+		{Name: "BOOL", ScanType: reflect.TypeOf(false)},
+	}, got, cmp.Comparer(func(x, y reflect.Type) bool { return x == y })); diff != "" {
+		t.Errorf("(-want +got):\n%s", diff)
+	}
+}
@@ -3,8 +3,10 @@ package zetasqlite_test
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -232,6 +234,343 @@ func TestCreateTempTable(t *testing.T) {
 	}
 }
 
+func TestCreateTableIfNotExists(t *testing.T) {
+	ctx := context.Background()
+	db, err := sql.Open("zetasqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if _, err := db.ExecContext(ctx, "CREATE TABLE IF NOT EXISTS if_not_exists_table (id INT64, name STRING)"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.ExecContext(ctx, "INSERT if_not_exists_table (id, name) VALUES (1, 'alice')"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.ExecContext(ctx, "CREATE TABLE IF NOT EXISTS if_not_exists_table (value FLOAT64)"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.ExecContext(ctx, "CREATE TABLE IF NOT EXISTS if_not_exists_table AS SELECT 1.5 AS value"); err != nil {
+		t.Fatal(err)
+	}
+	var (
+		id   int64
+		name string
+	)
+	if err := db.QueryRowContext(ctx, "SELECT id, name FROM if_not_exists_table").Scan(&id, &name); err != nil {
+		t.Fatal(err)
+	}
+	if id != 1 || name != "alice" {
+		t.Fatalf("unexpected row: id = %d, name = %s", id, name)
+	}
+	if _, err := db.ExecContext(ctx, "SELECT value FROM if_not_exists_table"); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestInsertWithDefault(t *testing.T) {
+	ctx := context.Background()
+	db, err := sql.Open("zetasqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if _, err := db.ExecContext(ctx, `
+CREATE TABLE insert_default_table (
+  id INT64,
+  name STRING DEFAULT 'unknown',
+  score INT64
+);
+INSERT insert_default_table (id, name, score) VALUES (1, DEFAULT, DEFAULT), (2, 'bob', 10);
+INSERT insert_default_table (id) VALUES (3);
+`); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := db.QueryContext(ctx, "SELECT id, name, score FROM insert_default_table ORDER BY id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	type queryRow struct {
+		ID    int64
+		Name  string
+		Score *int64
+	}
+	var results []*queryRow
+	for rows.Next() {
+		var row queryRow
+		if err := rows.Scan(&row.ID, &row.Name, &row.Score); err != nil {
+			t.Fatal(err)
+		}
+		results = append(results, &row)
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+	score := int64(10)
+	if diff := cmp.Diff(results, []*queryRow{
+		{ID: 1, Name: "unknown"},
+		{ID: 2, Name: "bob", Score: &score},
+		{ID: 3, Name: "unknown"},
+	}); diff != "" {
+		t.Errorf("(-want +got):\n%s", diff)
+	}
+}
+
+func TestInsertWithCurrentTimeDefault(t *testing.T) {
+	// the current time specified for CREATE TABLE must not be used as the default value.
+	ctx := zetasqlite.WithCurrentTime(context.Background(), time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC))
+	db, err := sql.Open("zetasqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if _, err := db.ExecContext(ctx, `
+CREATE TABLE insert_current_time_default_table (
+  id INT64,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP()
+)`); err != nil {
+		t.Fatal(err)
+	}
+	before := time.Now()
+	if _, err := db.Exec(`
+INSERT insert_current_time_default_table (id) VALUES (1);
+INSERT insert_current_time_default_table (id, created_at) VALUES (2, DEFAULT);
+`); err != nil {
+		t.Fatal(err)
+	}
+	after := time.Now()
+	rows, err := db.Query(`SELECT id, UNIX_MICROS(created_at) FROM insert_current_time_default_table ORDER BY id`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	var ids []int64
+	for rows.Next() {
+		var (
+			id     int64
+			micros int64
+		)
+		if err := rows.Scan(&id, &micros); err != nil {
+			t.Fatal(err)
+		}
+		if micros < before.UnixMicro() || micros > after.UnixMicro() {
+			t.Errorf("unexpected created_at %d of id %d", micros, id)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]int64{1, 2}, ids); diff != "" {
+		t.Errorf("(-want +got):\n%s", diff)
+	}
+}
+
+func multiRowInsertQuery(table string, rowNum int) string {
+	values := make([]string, 0, rowNum)
+	for i := 0; i < rowNum; i++ {
+		values = append(values, fmt.Sprintf("(%d, 'name_%d')", i, i))
+	}
+	return fmt.Sprintf("INSERT `%s` (id, name) VALUES %s", table, strings.Join(values, ","))
+}
+
+func TestMultiRowInsert(t *testing.T) {
+	const rowNum = 5000
+	ctx := context.Background()
+	db, err := sql.Open("zetasqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if _, err := db.ExecContext(ctx, "CREATE TABLE multi_row_table (id INT64, name STRING)"); err != nil {
+		t.Fatal(err)
+	}
+	result, err := db.ExecContext(ctx, multiRowInsertQuery("multi_row_table", rowNum))
+	if err != nil {
+		t.Fatal(err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if affected != rowNum {
+		t.Fatalf("failed to get rows affected: expected %d but got %d", rowNum, affected)
+	}
+	var (
+		count int64
+		sum   int64
+		name  string
+	)
+	if err := db.QueryRowContext(
+		ctx,
+		"SELECT COUNT(*), SUM(id), MAX(IF(id = 4999, name, NULL)) FROM multi_row_table",
+	).Scan(&count, &sum, &name); err != nil {
+		t.Fatal(err)
+	}
+	if count != rowNum || sum != rowNum*(rowNum-1)/2 || name != "name_4999" {
+		t.Fatalf("unexpected result: count = %d, sum = %d, name = %s", count, sum, name)
+	}
+}
+
+func BenchmarkMultiRowInsert(b *testing.B) {
+	ctx := context.Background()
+	db, err := sql.Open("zetasqlite", ":memory:")
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer db.Close()
+	if _, err := db.ExecContext(ctx, "CREATE TABLE multi_row_table (id INT64, name STRING)"); err != nil {
+		b.Fatal(err)
+	}
+	query := multiRowInsertQuery("multi_row_table", 5000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := db.ExecContext(ctx, query); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestDistinctInScalarFunction(t *testing.T) {
+	ctx := context.Background()
+	db, err := sql.Open("zetasqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	for _, test := range []struct {
+		name        string
+		query       string
+		expectedErr string
+	}{
+		{
+			name:        "distinct in scalar function",
+			query:       "SELECT LENGTH(DISTINCT x) FROM UNNEST(['a']) AS x",
+			expectedErr: "failed to analyze: INVALID_ARGUMENT: Non-aggregate function LENGTH cannot be called with DISTINCT [at 1:8]",
+		},
+		{
+			name:        "distinct in nested scalar function",
+			query:       "SELECT COUNT(DISTINCT UPPER(DISTINCT x)) FROM UNNEST(['a']) AS x",
+			expectedErr: "failed to analyze: INVALID_ARGUMENT: Non-aggregate function UPPER cannot be called with DISTINCT [at 1:23]",
+		},
+	} {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			rows, err := db.QueryContext(ctx, test.query)
+			if err == nil {
+				rows.Close()
+				t.Fatal("expected error")
+			}
+			if err.Error() != test.expectedErr {
+				t.Fatalf("unexpected error message: expected %q but got %q", test.expectedErr, err.Error())
+			}
+		})
+	}
+}
+
+func TestDropIfExists(t *testing.T) {
+	ctx := context.Background()
+	for _, test := range []struct {
+		name        string
+		setup       string
+		query       string
+		expectedErr string
+	}{
+		{
+			name:  "drop table if exists with missing table",
+			query: "DROP TABLE IF EXISTS missing_table",
+		},
+		{
+			name:  "drop view if exists with missing view",
+			query: "DROP VIEW IF EXISTS missing_view",
+		},
+		{
+			name:  "drop function if exists with missing function",
+			query: "DROP FUNCTION IF EXISTS missing_func",
+		},
+		{
+			name: "drop if exists with existing objects",
+			setup: `
+CREATE TABLE drop_table (id INT64);
+CREATE VIEW drop_view AS SELECT * FROM drop_table;
+CREATE FUNCTION drop_func(x INT64) AS (x + 1);
+`,
+			query: `
+DROP VIEW IF EXISTS drop_view;
+DROP TABLE IF EXISTS drop_table;
+DROP FUNCTION IF EXISTS drop_func;
+`,
+		},
+		{
+			name:        "drop missing table",
+			query:       "DROP TABLE missing_table",
+			expectedErr: "failed to drop table: missing_table is not found",
+		},
+		{
+			name:        "drop missing view",
+			query:       "DROP VIEW missing_view",
+			expectedErr: "failed to drop view: missing_view is not found",
+		},
+		{
+			name:        "drop missing function",
+			query:       "DROP FUNCTION missing_func",
+			expectedErr: "failed to drop function: missing_func is not found",
+		},
+		{
+			name:        "drop view if exists with table",
+			setup:       "CREATE TABLE drop_table (id INT64)",
+			query:       "DROP VIEW IF EXISTS drop_table",
+			expectedErr: "failed to drop view: drop_table is not a view",
+		},
+		{
+			name: "drop table with view",
+			setup: `
+CREATE TABLE drop_table (id INT64);
+CREATE VIEW drop_view AS SELECT * FROM drop_table;
+`,
+			query:       "DROP TABLE drop_view",
+			expectedErr: "failed to drop table: drop_view is not a table",
+		},
+	} {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			db, err := sql.Open("zetasqlite", ":memory:")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer db.Close()
+			if test.setup != "" {
+				if _, err := db.ExecContext(ctx, test.setup); err != nil {
+					t.Fatal(err)
+				}
+			}
+			_, err = db.ExecContext(ctx, test.query)
+			if test.expectedErr != "" {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				if err.Error() != test.expectedErr {
+					t.Fatalf("unexpected error message: expected %q but got %q", test.expectedErr, err.Error())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if test.setup == "" {
+				return
+			}
+			if _, err := db.ExecContext(ctx, "SELECT * FROM drop_table"); err == nil {
+				t.Fatal("expected error for dropped table")
+			}
+			if _, err := db.ExecContext(ctx, "SELECT drop_func(1)"); err == nil {
+				t.Fatal("expected error for dropped function")
+			}
+		})
+	}
+}
+
 func TestWildcardTable(t *testing.T) {
 	ctx := context.Background()
 	db, err := sql.Open("zetasqlite", ":memory:")
@@ -263,6 +602,12 @@ func TestWildcardTable(t *testing.T) {
 	); err != nil {
 		t.Fatal(err)
 	}
+	if _, err := db.ExecContext(
+		ctx,
+		"CREATE TABLE `project.dataset.subtable_e` AS SELECT name FROM UNNEST(['alice_e', 'bob_e']) as name",
+	); err != nil {
+		t.Fatal(err)
+	}
 	t.Run("with first identifier", func(t *testing.T) {
 		rows, err := db.QueryContext(ctx, "SELECT name, _TABLE_SUFFIX FROM `project.dataset.table_*` WHERE name LIKE 'alice%' OR name IS NULL")
 		if err != nil {
@@ -337,6 +682,78 @@ func TestWildcardTable(t *testing.T) {
 			t.Errorf("(-want +got):\n%s", diff)
 		}
 	})
+	t.Run("group by table suffix", func(t *testing.T) {
+		rows, err := db.QueryContext(ctx, "SELECT _table_suffix AS suffix, COUNT(*) FROM `project.dataset.table_*` GROUP BY _TABLE_SUFFIX ORDER BY suffix")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer rows.Close()
+		type queryRow struct {
+			Suffix string
+			Count  int64
+		}
+		var results []*queryRow
+		for rows.Next() {
+			var (
+				suffix string
+				count  int64
+			)
+			if err := rows.Scan(&suffix, &count); err != nil {
+				t.Fatal(err)
+			}
+			results = append(results, &queryRow{
+				Suffix: suffix,
+				Count:  count,
+			})
+		}
+		if err := rows.Err(); err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(results, []*queryRow{
+			{Suffix: "a", Count: 2},
+			{Suffix: "b", Count: 2},
+			{Suffix: "c", Count: 2},
+		}); diff != "" {
+			t.Errorf("(-want +got):\n%s", diff)
+		}
+	})
+	t.Run("join with table suffix", func(t *testing.T) {
+		rows, err := db.QueryContext(ctx, `
+SELECT t.name, s.label FROM `+"`project.dataset.table_*`"+` AS t
+JOIN UNNEST([STRUCT('b' AS suffix, 'B' AS label)]) AS s ON t._TABLE_SUFFIX = s.suffix
+ORDER BY t.name`)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer rows.Close()
+		type queryRow struct {
+			Name  string
+			Label string
+		}
+		var results []*queryRow
+		for rows.Next() {
+			var (
+				name  string
+				label string
+			)
+			if err := rows.Scan(&name, &label); err != nil {
+				t.Fatal(err)
+			}
+			results = append(results, &queryRow{
+				Name:  name,
+				Label: label,
+			})
+		}
+		if err := rows.Err(); err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(results, []*queryRow{
+			{Name: "alice_b", Label: "B"},
+			{Name: "bob_b", Label: "B"},
+		}); diff != "" {
+			t.Errorf("(-want +got):\n%s", diff)
+		}
+	})
 }
 
 func TestTemplatedArgFunc(t *testing.T) {
@@ -632,3 +1049,809 @@ LANGUAGE js AS """
 		}
 	})
 }
+
+func TestCurrentTimeFunctions(t *testing.T) {
+	now := time.Date(2022, 12, 31, 20, 30, 45, 123456000, time.UTC)
+	ctx := context.Background()
+	ctx = zetasqlite.WithCurrentTime(ctx, now)
+	db, err := sql.Open("zetasqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	t.Run("default time zone", func(t *testing.T) {
+		var (
+			date      string
+			datetime  string
+			timeValue string
+			micros    int64
+		)
+		if err := db.QueryRowContext(
+			ctx,
+			`SELECT CURRENT_DATE(), CURRENT_DATETIME(), CURRENT_TIME(), UNIX_MICROS(CURRENT_TIMESTAMP())`,
+		).Scan(&date, &datetime, &timeValue, &micros); err != nil {
+			t.Fatal(err)
+		}
+		if date != "2022-12-31" {
+			t.Fatalf("unexpected current date: %s", date)
+		}
+		if datetime != "2022-12-31T20:30:45.123456" {
+			t.Fatalf("unexpected current datetime: %s", datetime)
+		}
+		if timeValue != "20:30:45.123456" {
+			t.Fatalf("unexpected current time: %s", timeValue)
+		}
+		if micros != now.UnixMicro() {
+			t.Fatalf("unexpected current timestamp: %d", micros)
+		}
+	})
+	t.Run("with time zone", func(t *testing.T) {
+		var (
+			date      string
+			datetime  string
+			timeValue string
+		)
+		if err := db.QueryRowContext(
+			ctx,
+			`SELECT CURRENT_DATE("Asia/Tokyo"), CURRENT_DATETIME("Asia/Tokyo"), CURRENT_TIME("Asia/Tokyo")`,
+		).Scan(&date, &datetime, &timeValue); err != nil {
+			t.Fatal(err)
+		}
+		if date != "2023-01-01" {
+			t.Fatalf("unexpected current date: %s", date)
+		}
+		if datetime != "2023-01-01T05:30:45.123456" {
+			t.Fatalf("unexpected current datetime: %s", datetime)
+		}
+		if timeValue != "05:30:45.123456" {
+			t.Fatalf("unexpected current time: %s", timeValue)
+		}
+	})
+	t.Run("stable within a query", func(t *testing.T) {
+		rows, err := db.QueryContext(
+			context.Background(),
+			`SELECT UNIX_MICROS(CURRENT_TIMESTAMP()), UNIX_MICROS(CURRENT_TIMESTAMP()) FROM UNNEST(GENERATE_ARRAY(1, 100))`,
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer rows.Close()
+		var first int64
+		for rows.Next() {
+			var a, b int64
+			if err := rows.Scan(&a, &b); err != nil {
+				t.Fatal(err)
+			}
+			if a != b {
+				t.Fatalf("CURRENT_TIMESTAMP returns different values in the same row: %d and %d", a, b)
+			}
+			if first == 0 {
+				first = a
+			} else if first != a {
+				t.Fatalf("CURRENT_TIMESTAMP returns different values in the same query: %d and %d", first, a)
+			}
+		}
+		if err := rows.Err(); err != nil {
+			t.Fatal(err)
+		}
+		if first == 0 {
+			t.Fatal("failed to get results")
+		}
+	})
+}
+
+func TestScriptErrorPosition(t *testing.T) {
+	ctx := context.Background()
+	for _, test := range []struct {
+		name           string
+		query          string
+		expectedPrefix string
+		expectedErr    string
+	}{
+		{
+			name: "analysis error",
+			query: `CREATE TABLE script_table (id INT64);
+INSERT script_table (id) VALUES (1);
+  SELECT * FROM missing_table;
+SELECT * FROM script_table`,
+			expectedPrefix: "failed to run statement 3 [at 3:3]: failed to analyze: ",
+		},
+		{
+			name: "runtime error",
+			query: `CREATE TABLE script_table (id INT64);
+INSERT script_table (id) VALUES (DIV(1, 0));
+SELECT * FROM script_table`,
+			expectedPrefix: "failed to run statement 2 [at 2:1]: failed to exec ",
+		},
+		{
+			name:        "single statement",
+			query:       `DROP TABLE missing_table`,
+			expectedErr: "failed to drop table: missing_table is not found",
+		},
+	} {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			db, err := sql.Open("zetasqlite", ":memory:")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer db.Close()
+			_, err = db.ExecContext(ctx, test.query)
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if test.expectedErr != "" && err.Error() != test.expectedErr {
+				t.Fatalf("unexpected error message: expected %q but got %q", test.expectedErr, err.Error())
+			}
+			if test.expectedPrefix != "" && !strings.HasPrefix(err.Error(), test.expectedPrefix) {
+				t.Fatalf("unexpected error message: expected prefix %q but got %q", test.expectedPrefix, err.Error())
+			}
+		})
+	}
+}
+
+func TestQueryCancel(t *testing.T) {
+	db, err := sql.Open("zetasqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	time.AfterFunc(100*time.Millisecond, cancel)
+
+	start := time.Now()
+	err = func() error {
+		rows, err := db.QueryContext(
+			ctx,
+			`SELECT COUNT(*) FROM UNNEST(GENERATE_ARRAY(1, 100000)) AS a, UNNEST(GENERATE_ARRAY(1, 100000)) AS b`,
+		)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+		for rows.Next() {
+		}
+		return rows.Err()
+	}()
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled but got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("query was not aborted promptly: %s", elapsed)
+	}
+}
+
+func TestTableSampleWithRandSeed(t *testing.T) {
+	db, err := sql.Open("zetasqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	ctx := zetasqlite.WithRandSeed(context.Background(), 42)
+	if _, err := db.ExecContext(ctx, `
+CREATE TABLE sample_table (id INT64);
+INSERT sample_table (id) SELECT id FROM UNNEST(GENERATE_ARRAY(1, 100)) AS id;
+`); err != nil {
+		t.Fatal(err)
+	}
+	sample := func() []int64 {
+		rows, err := db.QueryContext(ctx, `SELECT id FROM sample_table TABLESAMPLE SYSTEM (20 PERCENT)`)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer rows.Close()
+		var ids []int64
+		for rows.Next() {
+			var id int64
+			if err := rows.Scan(&id); err != nil {
+				t.Fatal(err)
+			}
+			ids = append(ids, id)
+		}
+		if err := rows.Err(); err != nil {
+			t.Fatal(err)
+		}
+		return ids
+	}
+	first := sample()
+	if len(first) == 0 || len(first) == 100 {
+		t.Fatalf("unexpected number of sampled rows: %d", len(first))
+	}
+	if diff := cmp.Diff(first, sample()); diff != "" {
+		t.Errorf("(-want +got):\n%s", diff)
+	}
+}
+
+func TestExplain(t *testing.T) {
+	db, err := sql.Open("zetasqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if _, err := db.Exec(`
+CREATE TABLE explain_table (id INT64, name STRING);
+INSERT explain_table (id, name) VALUES (1, 'alice');
+`); err != nil {
+		t.Fatal(err)
+	}
+	t.Run("query", func(t *testing.T) {
+		var (
+			query  string
+			params []string
+		)
+		if err := db.QueryRow(`EXPLAIN SELECT name FROM explain_table WHERE id = @id AND name != @name`).Scan(
+			&query, zetasqlite.Array(&params),
+		); err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(query, "`explain_table`") || !strings.Contains(query, "@id") {
+			t.Errorf("unexpected query %s", query)
+		}
+		if diff := cmp.Diff([]string{"@id", "@name"}, params); diff != "" {
+			t.Errorf("(-want +got):\n%s", diff)
+		}
+	})
+	t.Run("dml is not executed", func(t *testing.T) {
+		var query string
+		if err := db.QueryRow(`EXPLAIN DELETE FROM explain_table WHERE TRUE`).Scan(&query, new(interface{})); err != nil {
+			t.Fatal(err)
+		}
+		if !strings.HasPrefix(query, "DELETE") {
+			t.Errorf("unexpected query %s", query)
+		}
+		var count int64
+		if err := db.QueryRow(`SELECT COUNT(*) FROM explain_table`).Scan(&count); err != nil {
+			t.Fatal(err)
+		}
+		if count != 1 {
+			t.Fatalf("expected 1 row but got %d", count)
+		}
+	})
+}
+
+func TestStmtCache(t *testing.T) {
+	db, err := sql.Open("zetasqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if _, err := db.Exec(`CREATE TABLE stmt_cache_table (id INT64, name STRING)`); err != nil {
+		t.Fatal(err)
+	}
+	t.Run("repeated parameterized statements", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			if _, err := db.Exec(`INSERT stmt_cache_table (id, name) VALUES (?, ?)`, int64(i), fmt.Sprint(i)); err != nil {
+				t.Fatal(err)
+			}
+		}
+		for i := 0; i < 3; i++ {
+			var name string
+			if err := db.QueryRow(`SELECT name FROM stmt_cache_table WHERE id = ?`, int64(i)).Scan(&name); err != nil {
+				t.Fatal(err)
+			}
+			if name != fmt.Sprint(i) {
+				t.Fatalf("expected %d but got %s", i, name)
+			}
+		}
+	})
+	t.Run("invalidated by catalog change", func(t *testing.T) {
+		query := `SELECT * FROM stmt_cache_table`
+		columns := func() []string {
+			rows, err := db.Query(query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer rows.Close()
+			cols, err := rows.Columns()
+			if err != nil {
+				t.Fatal(err)
+			}
+			return cols
+		}
+		if diff := cmp.Diff([]string{"id", "name"}, columns()); diff != "" {
+			t.Errorf("(-want +got):\n%s", diff)
+		}
+		if _, err := db.Exec(`
+DROP TABLE stmt_cache_table;
+CREATE TABLE stmt_cache_table (id INT64, name STRING, age INT64);
+`); err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]string{"id", "name", "age"}, columns()); diff != "" {
+			t.Errorf("(-want +got):\n%s", diff)
+		}
+	})
+	t.Run("current time is not cached", func(t *testing.T) {
+		for _, now := range []time.Time{
+			time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		} {
+			var micros int64
+			if err := db.QueryRowContext(
+				zetasqlite.WithCurrentTime(context.Background(), now),
+				`SELECT UNIX_MICROS(CURRENT_TIMESTAMP())`,
+			).Scan(&micros); err != nil {
+				t.Fatal(err)
+			}
+			if micros != now.UnixMicro() {
+				t.Fatalf("expected %d but got %d", now.UnixMicro(), micros)
+			}
+		}
+	})
+}
+
+func TestAlterTable(t *testing.T) {
+	db, err := sql.Open("zetasqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if _, err := db.Exec(`
+CREATE TABLE alter_table (id INT64, name STRING);
+INSERT alter_table (id, name) VALUES (1, 'alice');
+`); err != nil {
+		t.Fatal(err)
+	}
+	columns := func(t *testing.T) []string {
+		rows, err := db.Query(`SELECT * FROM alter_table`)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer rows.Close()
+		cols, err := rows.Columns()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return cols
+	}
+	t.Run("add column", func(t *testing.T) {
+		if _, err := db.Exec(`ALTER TABLE alter_table ADD COLUMN age INT64`); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := db.Exec(`INSERT alter_table (id, name, age) VALUES (2, 'bob', 20)`); err != nil {
+			t.Fatal(err)
+		}
+		rows, err := db.Query(`SELECT id, age FROM alter_table ORDER BY id`)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer rows.Close()
+		var ages []sql.NullInt64
+		for rows.Next() {
+			var (
+				id  int64
+				age sql.NullInt64
+			)
+			if err := rows.Scan(&id, &age); err != nil {
+				t.Fatal(err)
+			}
+			ages = append(ages, age)
+		}
+		if err := rows.Err(); err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]sql.NullInt64{{}, {Int64: 20, Valid: true}}, ages); diff != "" {
+			t.Errorf("(-want +got):\n%s", diff)
+		}
+		if _, err := db.Exec(`ALTER TABLE alter_table ADD COLUMN IF NOT EXISTS age INT64`); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := db.Exec(`ALTER TABLE alter_table ADD COLUMN age INT64`); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+	t.Run("rename column", func(t *testing.T) {
+		if _, err := db.Exec(`ALTER TABLE alter_table RENAME COLUMN name TO full_name`); err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]string{"id", "full_name", "age"}, columns(t)); diff != "" {
+			t.Errorf("(-want +got):\n%s", diff)
+		}
+		var name string
+		if err := db.QueryRow(`SELECT full_name FROM alter_table WHERE id = 1`).Scan(&name); err != nil {
+			t.Fatal(err)
+		}
+		if name != "alice" {
+			t.Fatalf("expected alice but got %s", name)
+		}
+	})
+	t.Run("drop column", func(t *testing.T) {
+		if _, err := db.Exec(`ALTER TABLE alter_table DROP COLUMN age`); err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]string{"id", "full_name"}, columns(t)); diff != "" {
+			t.Errorf("(-want +got):\n%s", diff)
+		}
+		if _, err := db.Exec(`ALTER TABLE alter_table DROP COLUMN IF EXISTS age`); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := db.Exec(`ALTER TABLE alter_table DROP COLUMN age`); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+	t.Run("changed catalog", func(t *testing.T) {
+		result, err := db.Exec(`ALTER TABLE alter_table ADD COLUMN note STRING`)
+		if err != nil {
+			t.Fatal(err)
+		}
+		changed, err := zetasqlite.ChangedCatalogFromResult(result)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(changed.Table.Updated) != 1 {
+			t.Fatal("failed to get updated table spec")
+		}
+		var names []string
+		for _, col := range changed.Table.Updated[0].Columns {
+			names = append(names, col.Name)
+		}
+		if diff := cmp.Diff([]string{"id", "full_name", "note"}, names); diff != "" {
+			t.Errorf("(-want +got):\n%s", diff)
+		}
+	})
+	t.Run("rollback all actions on failure", func(t *testing.T) {
+		if _, err := db.Exec(`ALTER TABLE alter_table ADD COLUMN a INT64, ADD COLUMN b INT64 NOT NULL`); err == nil {
+			t.Fatal("expected error")
+		}
+		if diff := cmp.Diff([]string{"id", "full_name", "note"}, columns(t)); diff != "" {
+			t.Errorf("(-want +got):\n%s", diff)
+		}
+		if _, err := db.Exec(`ALTER TABLE alter_table ADD COLUMN a INT64`); err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]string{"id", "full_name", "note", "a"}, columns(t)); diff != "" {
+			t.Errorf("(-want +got):\n%s", diff)
+		}
+	})
+	t.Run("drop renamed column with auto index", func(t *testing.T) {
+		ctx := context.Background()
+		conn, err := db.Conn(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer conn.Close()
+		if err := conn.Raw(func(c interface{}) error {
+			zetasqliteConn, ok := c.(*zetasqlite.ZetaSQLiteConn)
+			if !ok {
+				return fmt.Errorf("failed to get ZetaSQLiteConn from %T", c)
+			}
+			zetasqliteConn.SetAutoIndexMode(true)
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+		for _, query := range []string{
+			`CREATE TABLE alter_indexed_table (id INT64, name STRING)`,
+			`ALTER TABLE alter_indexed_table RENAME COLUMN name TO full_name`,
+			`ALTER TABLE alter_indexed_table DROP COLUMN full_name`,
+		} {
+			if _, err := conn.ExecContext(ctx, query); err != nil {
+				t.Fatalf("failed to exec %s: %v", query, err)
+			}
+		}
+	})
+}
+
+func TestFloatType(t *testing.T) {
+	db, err := sql.Open("zetasqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if _, err := db.Exec(`CREATE TABLE float_table (id INT64, floatValue FLOAT, doubleValue DOUBLE)`); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec(`INSERT float_table (id, floatValue, doubleValue) VALUES (1, 0.1, 0.1)`); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec(`INSERT float_table (id, floatValue, doubleValue) VALUES (2, ?, ?)`, 0.1, 0.1); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := db.Query(`SELECT floatValue, doubleValue, floatValue = doubleValue FROM float_table ORDER BY id`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			floatValue  float64
+			doubleValue float64
+			equal       bool
+		)
+		if err := rows.Scan(&floatValue, &doubleValue, &equal); err != nil {
+			t.Fatal(err)
+		}
+		if floatValue != float64(float32(0.1)) {
+			t.Errorf("expected single precision value %v but got %v", float64(float32(0.1)), floatValue)
+		}
+		if doubleValue != 0.1 {
+			t.Errorf("expected 0.1 but got %v", doubleValue)
+		}
+		if equal {
+			t.Error("expected FLOAT and DOUBLE values are different")
+		}
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestJSONStringCast checks the error messages of the casts between JSON and STRING.
+// They are rejected by the analyzer, so TO_JSON_STRING / STRING and PARSE_JSON are used instead.
+func TestJSONStringCast(t *testing.T) {
+	db, err := sql.Open("zetasqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	for _, test := range []struct {
+		name        string
+		query       string
+		expectedErr string
+	}{
+		{
+			name:        "cast json to string",
+			query:       `SELECT CAST(JSON '{"a": 1}' AS STRING)`,
+			expectedErr: "Invalid cast from JSON to STRING",
+		},
+		{
+			name:        "cast string to json",
+			query:       `SELECT CAST('{"a": 1}' AS JSON)`,
+			expectedErr: "Invalid cast from STRING to JSON",
+		},
+	} {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			var v interface{}
+			err := db.QueryRow(test.query).Scan(&v)
+			if err == nil {
+				t.Fatalf("expected error but got %v", v)
+			}
+			if !strings.Contains(err.Error(), test.expectedErr) {
+				t.Fatalf("expected error contains [%s] but got [%s]", test.expectedErr, err.Error())
+			}
+		})
+	}
+}
+
+func TestScript(t *testing.T) {
+	db, err := sql.Open("zetasqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	queryInt64s := func(t *testing.T, query string) []int64 {
+		rows, err := db.Query(query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer rows.Close()
+		var values []int64
+		for rows.Next() {
+			var v int64
+			if err := rows.Scan(&v); err != nil {
+				t.Fatal(err)
+			}
+			values = append(values, v)
+		}
+		if err := rows.Err(); err != nil {
+			t.Fatal(err)
+		}
+		return values
+	}
+	t.Run("if", func(t *testing.T) {
+		for _, test := range []struct {
+			value    int64
+			expected int64
+		}{
+			{value: 1, expected: 10},
+			{value: 2, expected: 20},
+			{value: 3, expected: 30},
+		} {
+			got := queryInt64s(t, fmt.Sprintf(`
+DECLARE x INT64 DEFAULT %d;
+DECLARE y INT64;
+IF x = 1 THEN
+  SET y = 10;
+ELSEIF x = 2 THEN
+  SET y = 20;
+ELSE
+  SET y = 30;
+END IF;
+SELECT y;
+`, test.value))
+			if diff := cmp.Diff([]int64{test.expected}, got); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		}
+	})
+	t.Run("while", func(t *testing.T) {
+		got := queryInt64s(t, `
+DECLARE i, total INT64 DEFAULT 0;
+WHILE i < 5 DO
+  SET i = i + 1;
+  SET total = total + i;
+END WHILE;
+SELECT total;
+`)
+		if diff := cmp.Diff([]int64{15}, got); diff != "" {
+			t.Errorf("(-want +got):\n%s", diff)
+		}
+	})
+	t.Run("loop with break and continue", func(t *testing.T) {
+		if _, err := db.Exec(`CREATE TABLE script_loop (v INT64)`); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := db.Exec(`
+DECLARE i INT64 DEFAULT 0;
+LOOP
+  SET i = i + 1;
+  IF i > 6 THEN
+    BREAK;
+  END IF;
+  IF MOD(i, 2) = 0 THEN
+    CONTINUE;
+  END IF;
+  INSERT script_loop (v) VALUES (i);
+END LOOP;
+`); err != nil {
+			t.Fatal(err)
+		}
+		got := queryInt64s(t, `SELECT v FROM script_loop ORDER BY v`)
+		if diff := cmp.Diff([]int64{1, 3, 5}, got); diff != "" {
+			t.Errorf("(-want +got):\n%s", diff)
+		}
+	})
+	t.Run("leave labeled loop", func(t *testing.T) {
+		got := queryInt64s(t, `
+DECLARE i, j, total INT64 DEFAULT 0;
+outer_loop: WHILE i < 3 DO
+  SET i = i + 1;
+  SET j = 0;
+  REPEAT
+    SET j = j + 1;
+    IF i = 2 AND j = 2 THEN
+      LEAVE outer_loop;
+    END IF;
+    SET total = total + 1;
+  UNTIL j >= 3
+  END REPEAT;
+END WHILE;
+SELECT total;
+`)
+		if diff := cmp.Diff([]int64{4}, got); diff != "" {
+			t.Errorf("(-want +got):\n%s", diff)
+		}
+	})
+	t.Run("for in", func(t *testing.T) {
+		if _, err := db.Exec(`CREATE TABLE script_for (name STRING, v INT64)`); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := db.Exec(`
+FOR record IN (SELECT name, v FROM UNNEST([STRUCT('a' AS name, 1 AS v), ('b', 2), ('c', 3)]))
+DO
+  IF record.name = 'b' THEN
+    CONTINUE;
+  END IF;
+  INSERT script_for (name, v) VALUES (record.name, record.v * 10);
+END FOR;
+`); err != nil {
+			t.Fatal(err)
+		}
+		got := queryInt64s(t, `SELECT v FROM script_for ORDER BY name`)
+		if diff := cmp.Diff([]int64{10, 30}, got); diff != "" {
+			t.Errorf("(-want +got):\n%s", diff)
+		}
+	})
+	t.Run("set multiple variables", func(t *testing.T) {
+		got := queryInt64s(t, `
+DECLARE x, y INT64;
+SET (x, y) = (1, 2);
+SET (x, y) = (y * 10, x * 10);
+SELECT x + y;
+`)
+		if diff := cmp.Diff([]int64{30}, got); diff != "" {
+			t.Errorf("(-want +got):\n%s", diff)
+		}
+	})
+	t.Run("execute immediate", func(t *testing.T) {
+		if _, err := db.Exec(`CREATE TABLE script_dynamic (v INT64)`); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := db.Exec(`
+DECLARE i INT64 DEFAULT 0;
+WHILE i < 3 DO
+  SET i = i + 1;
+  EXECUTE IMMEDIATE CONCAT('INSERT script_dynamic (v) VALUES (', CAST(i AS STRING), ' * @scale)') USING 100 AS scale;
+END WHILE;
+`); err != nil {
+			t.Fatal(err)
+		}
+		got := queryInt64s(t, `
+DECLARE total INT64;
+EXECUTE IMMEDIATE 'SELECT SUM(v) + ? FROM script_dynamic' INTO total USING 4;
+EXECUTE IMMEDIATE 'SELECT ? * ?' USING total, 2;
+`)
+		if diff := cmp.Diff([]int64{1208}, got); diff != "" {
+			t.Errorf("(-want +got):\n%s", diff)
+		}
+	})
+	t.Run("execute immediate into requires a single row", func(t *testing.T) {
+		if _, err := db.Exec(`
+DECLARE x INT64;
+EXECUTE IMMEDIATE 'SELECT v FROM UNNEST([1, 2]) AS v' INTO x;
+`); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+	t.Run("column takes precedence over variable", func(t *testing.T) {
+		got := queryInt64s(t, `
+DECLARE v INT64 DEFAULT 100;
+SELECT v FROM UNNEST([1, 2]) AS v
+UNION ALL
+SELECT v;
+`)
+		if diff := cmp.Diff([]int64{1, 2, 100}, got); diff != "" {
+			t.Errorf("(-want +got):\n%s", diff)
+		}
+	})
+	t.Run("array subscript with variable", func(t *testing.T) {
+		got := queryInt64s(t, `
+DECLARE v INT64 DEFAULT 1;
+SELECT [1, 2][0] + v
+UNION ALL
+SELECT [10, 20, 30][v];
+`)
+		if diff := cmp.Diff([]int64{2, 20}, got); diff != "" {
+			t.Errorf("(-want +got):\n%s", diff)
+		}
+	})
+	t.Run("positional parameters with variables", func(t *testing.T) {
+		_, err := db.Exec(`
+DECLARE v INT64 DEFAULT 1;
+SELECT ?, v;
+`, 10)
+		if err == nil {
+			t.Fatal("expected error")
+		}
+		if !strings.Contains(err.Error(), "positional parameters can't be used") {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	t.Run("jump outside of loop", func(t *testing.T) {
+		for _, query := range []string{
+			`BREAK;`,
+			`
+DECLARE x INT64 DEFAULT 1;
+IF x = 1 THEN
+  CONTINUE;
+END IF;
+SELECT x;
+`,
+			`
+DECLARE i INT64 DEFAULT 0;
+outer_loop: WHILE i < 3 DO
+  SET i = i + 1;
+  LEAVE missing_loop;
+END WHILE;
+`,
+		} {
+			if _, err := db.Exec(query); err == nil {
+				t.Fatalf("expected error for %s", query)
+			}
+		}
+	})
+	t.Run("condition must be bool", func(t *testing.T) {
+		if _, err := db.Exec(`
+DECLARE x INT64 DEFAULT 1;
+IF x THEN
+  SELECT 1;
+END IF;
+`); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
@@ -0,0 +1,49 @@
+package zetasqlite
+
+import (
+	"github.com/goccy/go-zetasql/types"
+
+	internal "github.com/goccy/go-zetasqlite/internal"
+)
+
+type (
+	// Value represents a value passed to and returned from the functions registered by RegisterScalarFunction or RegisterAggregateFunction.
+	// NULL is represented by nil.
+	Value = internal.Value
+
+	// The scalar values that the registered functions receive as arguments and can return.
+	// Other values such as ARRAY or GEOGRAPHY are accessed through the methods of Value.
+	IntValue       = internal.IntValue
+	FloatValue     = internal.FloatValue
+	BoolValue      = internal.BoolValue
+	StringValue    = internal.StringValue
+	BytesValue     = internal.BytesValue
+	JsonValue      = internal.JsonValue
+	NumericValue   = internal.NumericValue
+	DateValue      = internal.DateValue
+	DatetimeValue  = internal.DatetimeValue
+	TimeValue      = internal.TimeValue
+	TimestampValue = internal.TimestampValue
+
+	// AggregateFunction represents an instance of the aggregate function registered by RegisterAggregateFunction.
+	// It is created for each group, and Step is called with the arguments of each row after DISTINCT and IGNORE NULLS are applied.
+	AggregateFunction = internal.AggregateFunction
+)
+
+// RegisterScalarFunction registers fn as the scalar function that can be called by name from SQL.
+// The function accepts any number of arguments of any type, and NULL arguments are passed as nil.
+// The returned value is converted to returnType ( e.g. IntValue is returned as FLOAT64 value for types.DOUBLE ).
+// Functions must be registered before opening a database, because they are bound to the catalog and
+// the connections when the database is opened.
+func RegisterScalarFunction(name string, returnType types.TypeKind, fn func(...Value) (Value, error)) error {
+	return internal.RegisterScalarFunction(name, returnType, fn)
+}
+
+// RegisterAggregateFunction registers the aggregate function that can be called by name from SQL.
+// newFn is called to create AggregateFunction for each group.
+// The bundled ZetaSQL analyzer cannot declare the OVER clause support for the functions defined outside of it,
+// so the registered function cannot be used as a window function.
+// Functions must be registered before opening a database in the same way as RegisterScalarFunction.
+func RegisterAggregateFunction(name string, returnType types.TypeKind, newFn func() AggregateFunction) error {
+	return internal.RegisterAggregateFunction(name, returnType, newFn)
+}
@@ -0,0 +1,114 @@
+package zetasqlite_test
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/goccy/go-zetasql/types"
+
+	zetasqlite "github.com/goccy/go-zetasqlite"
+)
+
+type productAggregator struct {
+	product float64
+}
+
+func (a *productAggregator) Step(args ...zetasqlite.Value) error {
+	if args[0] == nil {
+		return nil
+	}
+	f64, err := args[0].ToFloat64()
+	if err != nil {
+		return err
+	}
+	a.product *= f64
+	return nil
+}
+
+func (a *productAggregator) Done() (zetasqlite.Value, error) {
+	return zetasqlite.FloatValue(a.product), nil
+}
+
+// registerFunctionRun is used to give unique names to the functions and the database for each run,
+// because the registered functions and the catalog are shared in the process ( e.g. go test -count=2 ).
+var registerFunctionRun int
+
+func TestRegisterFunction(t *testing.T) {
+	registerFunctionRun++
+	var (
+		doubleName  = fmt.Sprintf("my_double_%d", registerFunctionRun)
+		productName = fmt.Sprintf("my_product_%d", registerFunctionRun)
+	)
+	if err := zetasqlite.RegisterScalarFunction(strings.ToUpper(doubleName), types.INT64, func(args ...zetasqlite.Value) (zetasqlite.Value, error) {
+		if args[0] == nil {
+			return nil, nil
+		}
+		return args[0].Add(args[0])
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := zetasqlite.RegisterAggregateFunction(productName, types.DOUBLE, func() zetasqlite.AggregateFunction {
+		return &productAggregator{product: 1}
+	}); err != nil {
+		t.Fatal(err)
+	}
+	// use a database that is not shared with other tests so that the catalog is created after the registration.
+	db, err := sql.Open("zetasqlite", fmt.Sprintf("file:register_function_%d?mode=memory&cache=shared", registerFunctionRun))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	t.Run("scalar", func(t *testing.T) {
+		var (
+			doubled   int64
+			nullValue sql.NullInt64
+		)
+		if err := db.QueryRow(fmt.Sprintf(`SELECT %s(21), %s(NULL)`, strings.ToUpper(doubleName), doubleName)).Scan(&doubled, &nullValue); err != nil {
+			t.Fatal(err)
+		}
+		if doubled != 42 {
+			t.Errorf("expected 42 but got %d", doubled)
+		}
+		if nullValue.Valid {
+			t.Errorf("expected NULL but got %d", nullValue.Int64)
+		}
+	})
+	t.Run("aggregate", func(t *testing.T) {
+		rows, err := db.Query(fmt.Sprintf(
+			`SELECT g, %s(x) FROM UNNEST([STRUCT(1 AS g, 2 AS x), (1, 3), (2, 4), (2, NULL)]) GROUP BY g ORDER BY g`,
+			strings.ToUpper(productName),
+		))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer rows.Close()
+		var products []float64
+		for rows.Next() {
+			var (
+				g       int64
+				product float64
+			)
+			if err := rows.Scan(&g, &product); err != nil {
+				t.Fatal(err)
+			}
+			products = append(products, product)
+		}
+		if err := rows.Err(); err != nil {
+			t.Fatal(err)
+		}
+		if len(products) != 2 || products[0] != 6 || products[1] != 4 {
+			t.Errorf("unexpected products %v", products)
+		}
+	})
+	t.Run("duplicated name", func(t *testing.T) {
+		if err := zetasqlite.RegisterScalarFunction(doubleName, types.INT64, nil); err == nil {
+			t.Fatal("expected error")
+		}
+		if err := zetasqlite.RegisterScalarFunction("concat", types.STRING, nil); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
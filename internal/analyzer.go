@@ -4,6 +4,8 @@ import (
 	"context"
 	"database/sql/driver"
 	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/goccy/go-zetasql"
@@ -12,12 +14,16 @@ import (
 	"github.com/goccy/go-zetasql/types"
 )
 
+// bareArrayElementPattern matches the error of ZetaSQL for the array element access without OFFSET or ORDINAL.
+var bareArrayElementPattern = regexp.MustCompile(`Array element access with array\[position\] is not supported.* \[at (\d+):(\d+)\]`)
+
 type Analyzer struct {
-	namePath        *NamePath
-	isAutoIndexMode bool
-	isExplainMode   bool
-	catalog         *Catalog
-	opt             *zetasql.AnalyzerOptions
+	namePath         *NamePath
+	isAutoIndexMode  bool
+	isExplainMode    bool
+	isRatNumericMode bool
+	catalog          *Catalog
+	opt              *zetasql.AnalyzerOptions
 }
 
 func NewAnalyzer(catalog *Catalog) (*Analyzer, error) {
@@ -43,6 +49,7 @@ func newAnalyzerOptions() (*zetasql.AnalyzerOptions, error) {
 		zetasql.FeatureBignumericType,
 		zetasql.FeatureV13DecimalAlias,
 		zetasql.FeatureCreateTableNotNull,
+		zetasql.FeatureV13ColumnDefaultValue,
 		zetasql.FeatureParameterizedTypes,
 		zetasql.FeatureTablesample,
 		zetasql.FeatureTimestampNanos,
@@ -52,6 +59,7 @@ func newAnalyzerOptions() (*zetasql.AnalyzerOptions, error) {
 		zetasql.FeatureV11OrderByCollate,
 		zetasql.FeatureV11SelectStarExceptReplace,
 		zetasql.FeatureV12SafeFunctionCall,
+		zetasql.FeatureV12GroupByStruct,
 		zetasql.FeatureJsonType,
 		zetasql.FeatureJsonArrayFunctions,
 		zetasql.FeatureJsonStrictNumberParsing,
@@ -75,6 +83,10 @@ func newAnalyzerOptions() (*zetasql.AnalyzerOptions, error) {
 		zetasql.FeatureV11WithOnSubquery,
 		zetasql.FeatureV13Pivot,
 		zetasql.FeatureV13Unpivot,
+		zetasql.FeatureAlterTableRenameColumn,
+		zetasql.FeatureV13ScriptLabel,
+		zetasql.FeatureV13Repeat,
+		zetasql.FeatureV13ForIn,
 	})
 	langOpt.SetSupportedStatementKinds([]ast.Kind{
 		ast.BeginStmt,
@@ -93,6 +105,8 @@ func newAnalyzerOptions() (*zetasql.AnalyzerOptions, error) {
 		ast.CreateTableFunctionStmt,
 		ast.CreateViewStmt,
 		ast.DropFunctionStmt,
+		ast.AlterTableStmt,
+		ast.ExplainStmt,
 	})
 	// Enable QUALIFY without WHERE
 	// https://github.com/google/zetasql/issues/124
@@ -114,6 +128,10 @@ func (a *Analyzer) SetExplainMode(enabled bool) {
 	a.isExplainMode = enabled
 }
 
+func (a *Analyzer) SetRatNumericMode(enabled bool) {
+	a.isRatNumericMode = enabled
+}
+
 func (a *Analyzer) NamePath() []string {
 	return a.namePath.path
 }
@@ -187,54 +205,214 @@ func (a *Analyzer) Analyze(ctx context.Context, conn *Conn, query string, args [
 	if err := a.catalog.Sync(ctx, conn); err != nil {
 		return nil, fmt.Errorf("failed to sync catalog: %w", err)
 	}
+	namePath, err := a.namePathWithDefault(ctx)
+	if err != nil {
+		return nil, err
+	}
+	catalogVersion := a.catalog.currentVersion()
+	cacheKey := newStmtCacheKey(namePath, query, args)
+	if useStmtCache(ctx) {
+		if entry := a.catalog.stmtCache.get(cacheKey, catalogVersion); entry != nil {
+			return []StmtActionFunc{func() (StmtAction, error) {
+				return a.newStmtActionFromCache(query, args, entry)
+			}}, nil
+		}
+	}
 	stmts, err := a.parseScript(query)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse statements: %w", err)
 	}
+	for _, stmt := range stmts {
+		if isScriptStmt(stmt) {
+			// the procedural statements are interpreted in order while executing the script.
+			return []StmtActionFunc{func() (StmtAction, error) {
+				return newScriptStmtAction(a, query, args, stmts), nil
+			}}, nil
+		}
+	}
 	funcMap := map[string]*FunctionSpec{}
-	for _, spec := range a.catalog.getFunctions(a.namePath) {
+	for _, spec := range a.catalog.getFunctions(namePath) {
 		funcMap[spec.FuncName()] = spec
 	}
 	actionFuncs := make([]StmtActionFunc, 0, len(stmts))
-	for _, stmt := range stmts {
+	for idx, stmt := range stmts {
 		stmt := stmt
+		var pos *scriptStmtPosition
+		if len(stmts) > 1 {
+			// errors of a multi-statement script report which statement failed.
+			pos = newScriptStmtPosition(query, idx, stmt)
+		}
 		actionFuncs = append(actionFuncs, func() (StmtAction, error) {
 			mode, err := a.getParameterMode(stmt)
 			if err != nil {
 				return nil, err
 			}
 			a.opt.SetParameterMode(mode)
-			out, err := zetasql.AnalyzeStatementFromParserAST(
-				query,
-				stmt,
-				a.catalog,
-				a.opt,
-			)
+			out, analyzedQuery, analyzedStmt, err := a.analyzeStatement(query, stmt)
 			if err != nil {
-				return nil, fmt.Errorf("failed to analyze: %w", err)
+				return nil, pos.wrapError(fmt.Errorf("failed to analyze: %w", err))
 			}
 			stmtNode := out.Statement()
-			ctx = a.context(ctx, funcMap, stmtNode, stmt)
-			action, err := a.newStmtAction(ctx, query, args, stmtNode)
+			cacheable := len(stmts) == 1 && useStmtCache(ctx)
+			ctx = a.context(ctx, namePath, funcMap, stmtNode, analyzedStmt)
+			ctx = withStmtCacheable(ctx, &cacheable)
+			action, err := a.newStmtAction(ctx, analyzedQuery, args, stmtNode)
 			if err != nil {
-				return nil, err
+				return nil, pos.wrapError(err)
+			}
+			if cacheable {
+				a.addStmtCache(cacheKey, catalogVersion, action)
 			}
 			if mode == zetasql.ParameterPositional {
 				args = args[len(action.Args()):]
 			}
+			if pos != nil {
+				return &scriptStmtAction{StmtAction: action, pos: pos}, nil
+			}
 			return action, nil
 		})
 	}
 	return actionFuncs, nil
 }
 
+// analyzeStatement analyzes stmt parsed from query.
+// BigQuery accesses an array element by the zero-based offset if the position isn't wrapped by OFFSET or ORDINAL,
+// but ZetaSQL doesn't support it. So stmt is analyzed again after wrapping the position with OFFSET,
+// and the rewritten statement and the text of it are returned in that case.
+func (a *Analyzer) analyzeStatement(query string, stmt parsed_ast.StatementNode) (*zetasql.AnalyzerOutput, string, parsed_ast.StatementNode, error) {
+	for {
+		out, err := zetasql.AnalyzeStatementFromParserAST(query, stmt, a.catalog, a.opt)
+		if err == nil {
+			return out, query, stmt, nil
+		}
+		rewritten, ok := offsetBareArrayElement(query, stmt, err)
+		if !ok {
+			return nil, "", nil, err
+		}
+		rewrittenStmt, parseErr := zetasql.ParseStatement(rewritten, a.opt.ParserOptions())
+		if parseErr != nil {
+			return nil, "", nil, err
+		}
+		query, stmt = rewritten, rewrittenStmt
+	}
+}
+
+// offsetBareArrayElement returns the text of stmt whose array element access reported by err is rewritten
+// from array[position] to array[OFFSET(position)].
+func offsetBareArrayElement(query string, stmt parsed_ast.StatementNode, err error) (string, bool) {
+	matched := bareArrayElementPattern.FindStringSubmatch(err.Error())
+	if len(matched) != 3 {
+		return "", false
+	}
+	line, _ := strconv.Atoi(matched[1])
+	column, _ := strconv.Atoi(matched[2])
+	offset := byteOffsetFromLineAndColumn(query, line, column)
+	var position *types.ParseLocationRange
+	_ = parsed_ast.Walk(stmt, func(node parsed_ast.Node) error {
+		elem, ok := node.(*parsed_ast.ArrayElementNode)
+		if !ok {
+			return nil
+		}
+		if loc := elem.Position().ParseLocationRange(); loc.Start().ByteOffset() == offset {
+			position = loc
+		}
+		return nil
+	})
+	if position == nil {
+		return "", false
+	}
+	loc := stmt.ParseLocationRange()
+	start, end := position.Start().ByteOffset(), position.End().ByteOffset()
+	return fmt.Sprintf(
+		"%sOFFSET(%s)%s",
+		query[loc.Start().ByteOffset():start],
+		query[start:end],
+		query[end:loc.End().ByteOffset()],
+	), true
+}
+
+// addStmtCache caches the formatted query of action so that the same query is not analyzed again.
+// Only the query and DML statements are cached, because the others change the catalog or the connection state.
+func (a *Analyzer) addStmtCache(key string, catalogVersion uint64, action StmtAction) {
+	entry := &stmtCacheEntry{key: key, catalogVersion: catalogVersion}
+	switch act := action.(type) {
+	case *QueryStmtAction:
+		entry.isQuery = true
+		entry.formattedQuery = act.formattedQuery
+		entry.params = act.params
+		entry.outputColumns = act.outputColumns
+	case *DMLStmtAction:
+		entry.formattedQuery = act.formattedQuery
+		entry.params = act.params
+	default:
+		return
+	}
+	a.catalog.stmtCache.add(entry)
+}
+
+func (a *Analyzer) newStmtActionFromCache(query string, args []driver.NamedValue, entry *stmtCacheEntry) (StmtAction, error) {
+	queryArgs, err := getArgsFromParams(args, entry.params)
+	if err != nil {
+		return nil, err
+	}
+	if entry.isQuery {
+		return &QueryStmtAction{
+			query:            query,
+			params:           entry.params,
+			args:             queryArgs,
+			formattedQuery:   entry.formattedQuery,
+			outputColumns:    entry.outputColumns,
+			isExplainMode:    a.isExplainMode,
+			isRatNumericMode: a.isRatNumericMode,
+		}, nil
+	}
+	return &DMLStmtAction{
+		query:          query,
+		params:         entry.params,
+		args:           queryArgs,
+		formattedQuery: entry.formattedQuery,
+	}, nil
+}
+
+// namePathWithDefault returns the name path used for the statements analyzed with ctx.
+// The project and dataset specified by WithDefaultProject and WithDefaultDataset replace
+// the first and second elements of the name path set to the connection.
+func (a *Analyzer) namePathWithDefault(ctx context.Context) (*NamePath, error) {
+	project := defaultProjectFromContext(ctx)
+	dataset := defaultDatasetFromContext(ctx)
+	if project == "" && dataset == "" {
+		return a.namePath, nil
+	}
+	path := append([]string{}, a.namePath.path...)
+	if project != "" {
+		if len(path) > 0 {
+			path[0] = project
+		} else {
+			path = []string{project}
+		}
+	}
+	if dataset != "" {
+		if len(path) > 1 {
+			path[1] = dataset
+		} else {
+			path = append(path, dataset)
+		}
+	}
+	namePath := &NamePath{maxNum: a.namePath.maxNum}
+	if err := namePath.setPath(path); err != nil {
+		return nil, fmt.Errorf("failed to set default project and dataset: %w", err)
+	}
+	return namePath, nil
+}
+
 func (a *Analyzer) context(
 	ctx context.Context,
+	namePath *NamePath,
 	funcMap map[string]*FunctionSpec,
 	stmtNode ast.StatementNode,
 	stmt parsed_ast.StatementNode) context.Context {
 	ctx = withAnalyzer(ctx, a)
-	ctx = withNamePath(ctx, a.namePath)
+	ctx = withNamePath(ctx, namePath)
 	ctx = withColumnRefMap(ctx, map[string]string{})
 	ctx = withTableNameToColumnListMap(ctx, map[string][]*ast.Column{})
 	ctx = withFuncMap(ctx, funcMap)
@@ -266,6 +444,7 @@ func (a *Analyzer) newStmtAction(ctx context.Context, query string, args []drive
 		return a.newCreateTableStmtAction(ctx, query, args, node.(*ast.CreateTableStmtNode))
 	case ast.CreateTableAsSelectStmt:
 		ctx = withUseColumnID(ctx)
+		ctx = withStatementCurrentTime(ctx)
 		return a.newCreateTableAsSelectStmtAction(ctx, query, args, node.(*ast.CreateTableAsSelectStmtNode))
 	case ast.CreateFunctionStmt:
 		return a.newCreateFunctionStmtAction(ctx, query, args, node.(*ast.CreateFunctionStmtNode))
@@ -276,16 +455,23 @@ func (a *Analyzer) newStmtAction(ctx context.Context, query string, args []drive
 		return a.newDropStmtAction(ctx, query, args, node.(*ast.DropStmtNode))
 	case ast.DropFunctionStmt:
 		return a.newDropFunctionStmtAction(ctx, query, args, node.(*ast.DropFunctionStmtNode))
+	case ast.AlterTableStmt:
+		return a.newAlterTableStmtAction(ctx, query, node.(*ast.AlterTableStmtNode))
 	case ast.InsertStmt, ast.UpdateStmt, ast.DeleteStmt:
+		ctx = withStatementCurrentTime(ctx)
 		return a.newDMLStmtAction(ctx, query, args, node)
 	case ast.TruncateStmt:
 		return a.newTruncateStmtAction(ctx, query, args, node.(*ast.TruncateStmtNode))
 	case ast.MergeStmt:
 		ctx = withUseColumnID(ctx)
+		ctx = withStatementCurrentTime(ctx)
 		return a.newMergeStmtAction(ctx, query, args, node.(*ast.MergeStmtNode))
 	case ast.QueryStmt:
 		ctx = withUseColumnID(ctx)
+		ctx = withStatementCurrentTime(ctx)
 		return a.newQueryStmtAction(ctx, query, args, node.(*ast.QueryStmtNode))
+	case ast.ExplainStmt:
+		return a.newExplainStmtAction(ctx, query, node.(*ast.ExplainStmtNode))
 	case ast.BeginStmt:
 		return a.newBeginStmtAction(ctx, query, args, node)
 	case ast.CommitStmt:
@@ -294,8 +480,11 @@ func (a *Analyzer) newStmtAction(ctx context.Context, query string, args []drive
 	return nil, fmt.Errorf("unsupported stmt %s", node.DebugString())
 }
 
-func (a *Analyzer) newCreateTableStmtAction(_ context.Context, query string, args []driver.NamedValue, node *ast.CreateTableStmtNode) (*CreateTableStmtAction, error) {
-	spec := newTableSpec(a.namePath, node)
+func (a *Analyzer) newCreateTableStmtAction(ctx context.Context, query string, args []driver.NamedValue, node *ast.CreateTableStmtNode) (*CreateTableStmtAction, error) {
+	spec, err := newTableSpec(ctx, namePathFromContext(ctx), node)
+	if err != nil {
+		return nil, err
+	}
 	params := getParamsFromNode(node)
 	queryArgs, err := getArgsFromParams(args, params)
 	if err != nil {
@@ -315,7 +504,10 @@ func (a *Analyzer) newCreateTableAsSelectStmtAction(ctx context.Context, _ strin
 	if err != nil {
 		return nil, err
 	}
-	spec := newTableAsSelectSpec(a.namePath, query, node)
+	spec, err := newTableAsSelectSpec(ctx, namePathFromContext(ctx), query, node)
+	if err != nil {
+		return nil, err
+	}
 	params := getParamsFromNode(node)
 	queryArgs, err := getArgsFromParams(args, params)
 	if err != nil {
@@ -337,13 +529,13 @@ func (a *Analyzer) newCreateFunctionStmtAction(ctx context.Context, query string
 		if err != nil {
 			return nil, err
 		}
-		templatedFuncSpec, err := newTemplatedFunctionSpec(ctx, a.namePath, node, realStmts)
+		templatedFuncSpec, err := newTemplatedFunctionSpec(ctx, namePathFromContext(ctx), node, realStmts)
 		if err != nil {
 			return nil, err
 		}
 		spec = templatedFuncSpec
 	} else {
-		funcSpec, err := newFunctionSpec(ctx, a.namePath, node)
+		funcSpec, err := newFunctionSpec(ctx, namePathFromContext(ctx), node)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create function spec: %w", err)
 		}
@@ -361,7 +553,7 @@ func (a *Analyzer) newCreateViewStmtAction(ctx context.Context, _ string, _ []dr
 	if err != nil {
 		return nil, err
 	}
-	spec := newTableAsViewSpec(a.namePath, query, node)
+	spec := newTableAsViewSpec(namePathFromContext(ctx), query, node)
 	return &CreateViewStmtAction{
 		query:   query,
 		spec:    spec,
@@ -452,10 +644,11 @@ func (a *Analyzer) newDropStmtAction(ctx context.Context, query string, args []d
 		return nil, err
 	}
 	objectType := node.ObjectType()
-	name := a.namePath.format(node.NamePath())
+	name := namePathFromContext(ctx).format(node.NamePath())
 	return &DropStmtAction{
 		name:           name,
 		objectType:     objectType,
+		isIfExists:     node.IsIfExists(),
 		funcMap:        funcMapFromContext(ctx),
 		catalog:        a.catalog,
 		query:          query,
@@ -470,10 +663,11 @@ func (a *Analyzer) newDropFunctionStmtAction(ctx context.Context, query string,
 	if err != nil {
 		return nil, err
 	}
-	name := a.namePath.format(node.NamePath())
+	name := namePathFromContext(ctx).format(node.NamePath())
 	return &DropStmtAction{
 		name:       name,
 		objectType: "FUNCTION",
+		isIfExists: node.IsIfExists(),
 		funcMap:    funcMapFromContext(ctx),
 		catalog:    a.catalog,
 		query:      query,
@@ -481,6 +675,47 @@ func (a *Analyzer) newDropFunctionStmtAction(ctx context.Context, query string,
 	}, nil
 }
 
+func (a *Analyzer) newAlterTableStmtAction(ctx context.Context, query string, node *ast.AlterTableStmtNode) (*AlterTableStmtAction, error) {
+	actions := make([]*alterColumnAction, 0, len(node.AlterActionList()))
+	for _, action := range node.AlterActionList() {
+		switch act := action.(type) {
+		case *ast.AddColumnActionNode:
+			columns, err := newColumnsFromDef(ctx, []*ast.ColumnDefinitionNode{act.ColumnDefinition()})
+			if err != nil {
+				return nil, err
+			}
+			actions = append(actions, &alterColumnAction{
+				kind:       ast.AddColumnAction,
+				column:     columns[0],
+				isIfExists: act.IsIfNotExists(),
+			})
+		case *ast.DropColumnActionNode:
+			actions = append(actions, &alterColumnAction{
+				kind:       ast.DropColumnAction,
+				name:       act.Name(),
+				isIfExists: act.IsIfExists(),
+			})
+		case *ast.RenameColumnActionNode:
+			actions = append(actions, &alterColumnAction{
+				kind:       ast.RenameColumnAction,
+				name:       act.Name(),
+				newName:    act.NewName(),
+				isIfExists: act.IsIfExists(),
+			})
+		default:
+			return nil, fmt.Errorf("currently unsupported ALTER TABLE action %s", action.DebugString())
+		}
+	}
+	return &AlterTableStmtAction{
+		query:           query,
+		name:            namePathFromContext(ctx).format(node.NamePath()),
+		isIfExists:      node.IsIfExists(),
+		actions:         actions,
+		catalog:         a.catalog,
+		isAutoIndexMode: a.isAutoIndexMode,
+	}, nil
+}
+
 func (a *Analyzer) newDMLStmtAction(ctx context.Context, query string, args []driver.NamedValue, node ast.Node) (*DMLStmtAction, error) {
 	formattedQuery, err := newNode(node).FormatSQL(ctx)
 	if err != nil {
@@ -523,15 +758,33 @@ func (a *Analyzer) newQueryStmtAction(ctx context.Context, query string, args []
 		return nil, err
 	}
 	return &QueryStmtAction{
-		query:          query,
-		params:         params,
-		args:           queryArgs,
-		formattedQuery: formattedQuery,
-		outputColumns:  outputColumns,
-		isExplainMode:  a.isExplainMode,
+		query:            query,
+		params:           params,
+		args:             queryArgs,
+		formattedQuery:   formattedQuery,
+		outputColumns:    outputColumns,
+		isExplainMode:    a.isExplainMode,
+		isRatNumericMode: a.isRatNumericMode,
 	}, nil
 }
 
+func (a *Analyzer) newExplainStmtAction(ctx context.Context, query string, node *ast.ExplainStmtNode) (*ExplainStmtAction, error) {
+	// the explained statement is only formatted, so the arguments are not bound to it.
+	action, err := a.newStmtAction(ctx, query, nil, node.Statement())
+	if err != nil {
+		return nil, err
+	}
+	switch act := action.(type) {
+	case *QueryStmtAction:
+		return &ExplainStmtAction{queries: []string{act.formattedQuery}, params: act.params}, nil
+	case *DMLStmtAction:
+		return &ExplainStmtAction{queries: []string{act.formattedQuery}, params: act.params}, nil
+	case *MergeStmtAction:
+		return &ExplainStmtAction{queries: act.stmts}, nil
+	}
+	return nil, fmt.Errorf("unsupported EXPLAIN for %s", node.Statement().DebugString())
+}
+
 func (a *Analyzer) newBeginStmtAction(ctx context.Context, query string, args []driver.NamedValue, node ast.Node) (*BeginStmtAction, error) {
 	return &BeginStmtAction{}, nil
 }
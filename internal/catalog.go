@@ -57,7 +57,9 @@ const (
 type Catalog struct {
 	db           *sql.DB
 	lastSyncedAt time.Time
+	version      uint64
 	mu           sync.Mutex
+	stmtCache    *stmtCache
 	tables       []*TableSpec
 	functions    []*FunctionSpec
 	catalog      *types.SimpleCatalog
@@ -68,15 +70,18 @@ type Catalog struct {
 func newSimpleCatalog(name string) *types.SimpleCatalog {
 	catalog := types.NewSimpleCatalog(name)
 	catalog.AddZetaSQLBuiltinFunctions(nil)
+	addExtraBuiltinFunctions(catalog)
+	addCustomFunctions(catalog)
 	return catalog
 }
 
 func NewCatalog(db *sql.DB) *Catalog {
 	return &Catalog{
-		db:       db,
-		catalog:  newSimpleCatalog(catalogName),
-		tableMap: map[string]*TableSpec{},
-		funcMap:  map[string]*FunctionSpec{},
+		db:        db,
+		catalog:   newSimpleCatalog(catalogName),
+		stmtCache: newStmtCache(defaultStmtCacheSize),
+		tableMap:  map[string]*TableSpec{},
+		funcMap:   map[string]*FunctionSpec{},
 	}
 }
 
@@ -171,6 +176,14 @@ func (c *Catalog) getFunctions(namePath *NamePath) []*FunctionSpec {
 	return specs
 }
 
+// currentVersion returns the version of the catalog which is updated whenever the specs are changed.
+// It is used to invalidate the statements cached before the change.
+func (c *Catalog) currentVersion() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.version
+}
+
 func (c *Catalog) Sync(ctx context.Context, conn *Conn) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
@@ -247,6 +260,21 @@ func (c *Catalog) AddNewFunctionSpec(ctx context.Context, conn *Conn, spec *Func
 	return nil
 }
 
+func (c *Catalog) UpdateTableSpec(ctx context.Context, conn *Conn, spec *TableSpec) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.addTableSpec(spec); err != nil {
+		return err
+	}
+	if !spec.IsTemp {
+		if err := c.saveTableSpec(ctx, conn, spec); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (c *Catalog) DeleteTableSpec(ctx context.Context, conn *Conn, name string) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
@@ -273,6 +301,22 @@ func (c *Catalog) DeleteFunctionSpec(ctx context.Context, conn *Conn, name strin
 	return nil
 }
 
+func (c *Catalog) lookupTableSpec(name string) (*TableSpec, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	spec, exists := c.tableMap[name]
+	return spec, exists
+}
+
+func (c *Catalog) existsFunctionSpec(name string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_, exists := c.funcMap[name]
+	return exists
+}
+
 func (c *Catalog) deleteTableSpecByName(name string) error {
 	spec, exists := c.tableMap[name]
 	if !exists {
@@ -312,6 +356,7 @@ func (c *Catalog) deleteFunctionSpecByName(name string) error {
 }
 
 func (c *Catalog) resetCatalog(tables []*TableSpec, functions []*FunctionSpec) error {
+	c.version++
 	c.catalog = newSimpleCatalog(catalogName)
 	c.tables = []*TableSpec{}
 	c.functions = []*FunctionSpec{}
@@ -411,6 +456,7 @@ func (c *Catalog) trimmedLastPath(path []string) []string {
 }
 
 func (c *Catalog) addFunctionSpec(spec *FunctionSpec) error {
+	c.version++
 	funcName := spec.FuncName()
 	if _, exists := c.funcMap[funcName]; exists {
 		c.funcMap[funcName] = spec // update current spec
@@ -425,10 +471,22 @@ func (c *Catalog) addFunctionSpec(spec *FunctionSpec) error {
 }
 
 func (c *Catalog) addTableSpec(spec *TableSpec) error {
+	c.version++
 	tableName := spec.TableName()
-	if _, exists := c.tableMap[tableName]; exists {
-		c.tableMap[tableName] = spec // update current spec
-		return nil
+	if current, exists := c.tableMap[tableName]; exists {
+		if current.UpdatedAt.Equal(spec.UpdatedAt) {
+			return nil
+		}
+		// rebuild the catalog to replace the columns of the table known by the analyzer.
+		tables := make([]*TableSpec, 0, len(c.tables))
+		for _, table := range c.tables {
+			if table.TableName() == tableName {
+				tables = append(tables, spec)
+				continue
+			}
+			tables = append(tables, table)
+		}
+		return c.resetCatalog(tables, c.functions)
 	}
 	c.tables = append(c.tables, spec)
 	c.tableMap[tableName] = spec
@@ -18,6 +18,8 @@ const (
 	TimeValueType       ValueType = "time"
 	TimestampValueType  ValueType = "timestamp"
 	IntervalValueType   ValueType = "interval"
+	GeographyValueType  ValueType = "geography"
+	RangeValueType      ValueType = "range"
 )
 
 type ValueLayout struct {
@@ -19,6 +19,12 @@ type (
 	analyticInputScanKey            struct{}
 	arraySubqueryColumnNameKey      struct{}
 	currentTimeKey                  struct{}
+	defaultProjectKey               struct{}
+	defaultDatasetKey               struct{}
+	randSeedKey                     struct{}
+	stmtCacheableKey                struct{}
+	withoutStmtCacheKey             struct{}
+	preparedStmtKey                 struct{}
 	tableNameToColumnListMapKey     struct{}
 	useColumnIDKey                  struct{}
 	useTableNameForColumnKey        struct{}
@@ -196,3 +202,94 @@ func CurrentTime(ctx context.Context) *time.Time {
 	}
 	return value.(*time.Time)
 }
+
+// withoutCurrentTime removes the current time from ctx,
+// so that CURRENT_* functions are evaluated when they are called instead of the time the statement is analyzed.
+func withoutCurrentTime(ctx context.Context) context.Context {
+	return context.WithValue(ctx, currentTimeKey{}, (*time.Time)(nil))
+}
+
+// WithDefaultProject sets the project used to resolve table and function names which are not qualified by it.
+func WithDefaultProject(ctx context.Context, project string) context.Context {
+	return context.WithValue(ctx, defaultProjectKey{}, project)
+}
+
+func defaultProjectFromContext(ctx context.Context) string {
+	value := ctx.Value(defaultProjectKey{})
+	if value == nil {
+		return ""
+	}
+	return value.(string)
+}
+
+// WithDefaultDataset sets the dataset used to resolve table and function names which are not qualified by it.
+func WithDefaultDataset(ctx context.Context, dataset string) context.Context {
+	return context.WithValue(ctx, defaultDatasetKey{}, dataset)
+}
+
+func defaultDatasetFromContext(ctx context.Context) string {
+	value := ctx.Value(defaultDatasetKey{})
+	if value == nil {
+		return ""
+	}
+	return value.(string)
+}
+
+// WithRandSeed sets the seed used to sample rows by TABLESAMPLE without REPEATABLE clause.
+func WithRandSeed(ctx context.Context, seed int64) context.Context {
+	return context.WithValue(ctx, randSeedKey{}, &seed)
+}
+
+func randSeedFromContext(ctx context.Context) *int64 {
+	value := ctx.Value(randSeedKey{})
+	if value == nil {
+		return nil
+	}
+	return value.(*int64)
+}
+
+// withStmtCacheable sets the flag which is cleared while formatting
+// if the formatted query depends on the values other than the query text ( e.g. the current time ).
+func withStmtCacheable(ctx context.Context, cacheable *bool) context.Context {
+	return context.WithValue(ctx, stmtCacheableKey{}, cacheable)
+}
+
+func disableStmtCache(ctx context.Context) {
+	value := ctx.Value(stmtCacheableKey{})
+	if value == nil {
+		return
+	}
+	*(value.(*bool)) = false
+}
+
+// withoutStmtCache disables the statement cache for the queries analyzed with ctx.
+func withoutStmtCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, withoutStmtCacheKey{}, true)
+}
+
+func useStmtCache(ctx context.Context) bool {
+	return ctx.Value(withoutStmtCacheKey{}) == nil
+}
+
+// WithPreparedStmt marks that the statements are prepared to be executed many times,
+// so the current time must not be fixed when they are analyzed.
+func WithPreparedStmt(ctx context.Context) context.Context {
+	return context.WithValue(ctx, preparedStmtKey{}, true)
+}
+
+func isPreparedStmt(ctx context.Context) bool {
+	value := ctx.Value(preparedStmtKey{})
+	if value == nil {
+		return false
+	}
+	return value.(bool)
+}
+
+// withStatementCurrentTime fixes the current time for a statement if it isn't specified by WithCurrentTime,
+// so that every CURRENT_* function call in the statement returns the same value as BigQuery does.
+func withStatementCurrentTime(ctx context.Context) context.Context {
+	if CurrentTime(ctx) != nil || isPreparedStmt(ctx) {
+		return ctx
+	}
+	return WithCurrentTime(ctx, time.Now())
+}
@@ -37,10 +37,48 @@ func DecodeValue(v interface{}) (Value, error) {
 	return decodeFromValueLayout(&layout)
 }
 
+// decodeArray decodes an encoded array value into a JSON array of encoded elements for json_each.
+// NULL is decoded as an empty array.
+func decodeArray(v interface{}) (string, error) {
+	decoded, err := DecodeValue(v)
+	if err != nil {
+		return "", err
+	}
+	if decoded == nil {
+		return "[]", nil
+	}
+	array, err := decoded.ToArray()
+	if err != nil {
+		return "", err
+	}
+	if array == nil {
+		return "[]", nil
+	}
+	encodedValues := make([]interface{}, 0, len(array.values))
+	for _, value := range array.values {
+		v, err := EncodeValue(value)
+		if err != nil {
+			return "", err
+		}
+		encodedValues = append(encodedValues, v)
+	}
+	b, err := json.Marshal(encodedValues)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
 func decodeFromValueLayout(layout *ValueLayout) (Value, error) {
 	switch layout.Header {
 	case StringValueType:
 		return StringValue(layout.Body), nil
+	case FloatValueType:
+		f64, err := strconv.ParseFloat(layout.Body, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse float value %s: %w", layout.Body, err)
+		}
+		return FloatValue(f64), nil
 	case BytesValueType:
 		decoded, err := base64.StdEncoding.DecodeString(layout.Body)
 		if err != nil {
@@ -48,12 +86,16 @@ func decodeFromValueLayout(layout *ValueLayout) (Value, error) {
 		}
 		return BytesValue(decoded), nil
 	case NumericValueType:
-		r := new(big.Rat)
-		r.SetString(layout.Body)
+		r, ok := new(big.Rat).SetString(layout.Body)
+		if !ok {
+			return nil, fmt.Errorf("failed to parse numeric value %s", layout.Body)
+		}
 		return &NumericValue{Rat: r}, nil
 	case BigNumericValueType:
-		r := new(big.Rat)
-		r.SetString(layout.Body)
+		r, ok := new(big.Rat).SetString(layout.Body)
+		if !ok {
+			return nil, fmt.Errorf("failed to parse bignumeric value %s", layout.Body)
+		}
 		return &NumericValue{Rat: r, isBigNumeric: true}, nil
 	case DateValueType:
 		t, err := parseDate(layout.Body)
@@ -84,6 +126,25 @@ func decodeFromValueLayout(layout *ValueLayout) (Value, error) {
 		return TimestampValue(time.Unix(sec, remainder*int64(time.Microsecond))), nil
 	case IntervalValueType:
 		return parseInterval(layout.Body)
+	case GeographyValueType:
+		return parseGeography(layout.Body)
+	case RangeValueType:
+		var boundaries []interface{}
+		if err := json.Unmarshal([]byte(layout.Body), &boundaries); err != nil {
+			return nil, fmt.Errorf("failed to decode range body: %w", err)
+		}
+		if len(boundaries) != 2 {
+			return nil, fmt.Errorf("failed to decode range value: unexpected boundaries length %d", len(boundaries))
+		}
+		start, err := DecodeValue(boundaries[0])
+		if err != nil {
+			return nil, err
+		}
+		end, err := DecodeValue(boundaries[1])
+		if err != nil {
+			return nil, err
+		}
+		return &RangeValue{Start: start, End: end}, nil
 	case JsonValueType:
 		return JsonValue(layout.Body), nil
 	case ArrayValueType:
@@ -107,6 +168,12 @@ func decodeFromValueLayout(layout *ValueLayout) (Value, error) {
 		if err := json.Unmarshal([]byte(layout.Body), &structLayout); err != nil {
 			return nil, err
 		}
+		if len(structLayout.Keys) != len(structLayout.Values) {
+			return nil, fmt.Errorf(
+				"failed to decode struct value: mismatch keys length %d and values length %d",
+				len(structLayout.Keys), len(structLayout.Values),
+			)
+		}
 		m := map[string]Value{}
 		values := make([]Value, 0, len(structLayout.Values))
 		for i, data := range structLayout.Values {
@@ -0,0 +1,74 @@
+package internal
+
+import (
+	"encoding/base64"
+	"testing"
+)
+
+func TestDecodeArray(t *testing.T) {
+	encodeLayout := func(layout string) string {
+		return base64.StdEncoding.EncodeToString([]byte(layout))
+	}
+	t.Run("round trip", func(t *testing.T) {
+		encoded, err := EncodeValue(&ArrayValue{
+			values: []Value{IntValue(1), nil, StringValue("a")},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		decoded, err := decodeArray(encoded)
+		if err != nil {
+			t.Fatal(err)
+		}
+		encodedString, err := EncodeValue(StringValue("a"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := `[1,null,"` + encodedString.(string) + `"]`
+		if decoded != expected {
+			t.Fatalf("failed to decode array: expected %s but got %s", expected, decoded)
+		}
+	})
+	t.Run("null", func(t *testing.T) {
+		decoded, err := decodeArray(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if decoded != "[]" {
+			t.Fatalf("expected empty array but got %s", decoded)
+		}
+	})
+	t.Run("empty", func(t *testing.T) {
+		encoded, err := EncodeValue(&ArrayValue{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		decoded, err := decodeArray(encoded)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if decoded != "[]" {
+			t.Fatalf("expected empty array but got %s", decoded)
+		}
+	})
+	for _, test := range []struct {
+		name    string
+		encoded interface{}
+	}{
+		{name: "invalid base64", encoded: "!!!"},
+		{name: "invalid layout", encoded: encodeLayout(`{"header":`)},
+		{name: "invalid array body", encoded: encodeLayout(`{"header":"array","body":"[1,"}`)},
+		{name: "invalid element", encoded: encodeLayout(`{"header":"array","body":"[\"!!!\"]"}`)},
+		{name: "invalid numeric element", encoded: encodeLayout(`{"header":"numeric","body":"abc"}`)},
+		{name: "mismatch struct keys", encoded: encodeLayout(`{"header":"struct","body":"{\"keys\":[],\"values\":[1]}"}`)},
+		{name: "not array", encoded: encodeLayout(`{"header":"string","body":"a"}`)},
+		{name: "unexpected type", encoded: []byte{}},
+	} {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := decodeArray(test.encoded); err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
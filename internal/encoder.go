@@ -5,6 +5,7 @@ import (
 	"database/sql/driver"
 	"encoding/base64"
 	"fmt"
+	"math"
 	"math/big"
 	"reflect"
 	"regexp"
@@ -73,7 +74,10 @@ func EncodeValue(v Value) (interface{}, error) {
 	case IntValue:
 		return v.ToInt64()
 	case FloatValue:
-		return v.ToFloat64()
+		// SQLite stores NaN as NULL, so NaN is encoded with the value layout to keep it as a non-NULL value.
+		if !math.IsNaN(float64(vv)) {
+			return v.ToFloat64()
+		}
 	case BoolValue:
 		return v.ToBool()
 	case *SafeValue:
@@ -106,6 +110,10 @@ func LiteralFromValue(v Value) (string, error) {
 		if err != nil {
 			return "", err
 		}
+		if math.IsNaN(f64) {
+			// NaN can't be written as a numeric literal of SQLite.
+			break
+		}
 		value := strconv.FormatFloat(f64, 'g', -1, 64)
 		if !strings.Contains(value, ".") && !strings.Contains(value, "e") {
 			// append x.0 suffix to keep float value context
@@ -149,7 +157,15 @@ func ValueFromZetaSQLValue(v types.Value) (Value, error) {
 		return intValueFromLiteral(v.SQLLiteral(0))
 	case types.BOOL:
 		return boolValueFromLiteral(v.SQLLiteral(0))
-	case types.FLOAT, types.DOUBLE:
+	case types.FLOAT:
+		f64, err := floatValueFromLiteral(v.SQLLiteral(0))
+		if err != nil {
+			return nil, err
+		}
+		// the literal of FLOAT is the shortest representation of the single precision value,
+		// so it must be rounded again to keep the precision of FLOAT.
+		return FloatValue(float32(f64)), nil
+	case types.DOUBLE:
 		return floatValueFromLiteral(v.SQLLiteral(0))
 	case types.STRING:
 		return StringValue(v.StringValue()), nil
@@ -370,7 +386,17 @@ func CastValue(t types.Type, v Value) (Value, error) {
 			return nil, err
 		}
 		return BoolValue(b), nil
-	case types.FLOAT, types.DOUBLE:
+	case types.FLOAT:
+		f64, err := v.ToFloat64()
+		if err != nil {
+			return nil, err
+		}
+		f32 := float32(f64)
+		if math.IsInf(float64(f32), 0) && !math.IsInf(f64, 0) {
+			return nil, fmt.Errorf("failed to cast %v to FLOAT: value is out of range", f64)
+		}
+		return FloatValue(f32), nil
+	case types.DOUBLE:
 		f64, err := v.ToFloat64()
 		if err != nil {
 			return nil, err
@@ -482,12 +508,26 @@ func CastValue(t types.Type, v Value) (Value, error) {
 		}
 		return ret, nil
 	case types.NUMERIC:
+		if fv, ok := v.(FloatValue); ok {
+			r, err := roundFloatToNumeric(float64(fv), numericScale, minNumericValue, maxNumericValue)
+			if err != nil {
+				return nil, err
+			}
+			return &NumericValue{Rat: r}, nil
+		}
 		r, err := v.ToRat()
 		if err != nil {
 			return nil, err
 		}
 		return &NumericValue{Rat: r}, nil
 	case types.BIG_NUMERIC:
+		if fv, ok := v.(FloatValue); ok {
+			r, err := roundFloatToNumeric(float64(fv), bigNumericScale, minBigNumericValue, maxBigNumericValue)
+			if err != nil {
+				return nil, err
+			}
+			return &NumericValue{Rat: r, isBigNumeric: true}, nil
+		}
 		r, err := v.ToRat()
 		if err != nil {
 			return nil, err
@@ -500,7 +540,14 @@ func CastValue(t types.Type, v Value) (Value, error) {
 		}
 		return JsonValue(j), nil
 	case types.GEOGRAPHY:
-		return v, nil
+		if gv, ok := v.(*GeographyValue); ok {
+			return gv, nil
+		}
+		s, err := v.ToString()
+		if err != nil {
+			return nil, err
+		}
+		return parseGeography(s)
 	}
 	return nil, fmt.Errorf("unsupported cast %s value", t.Kind())
 }
@@ -509,6 +556,19 @@ func ValueFromGoValue(v interface{}) (Value, error) {
 	if isNullValue(v) {
 		return nil, nil
 	}
+	if value, ok := v.(Value); ok {
+		return value, nil
+	}
+	if valuer, ok := v.(driver.Valuer); ok {
+		vv, err := valuer.Value()
+		if err != nil {
+			return nil, err
+		}
+		if isNullValue(vv) {
+			return nil, nil
+		}
+		v = vv
+	}
 	return valueFromGoReflectValue(reflect.ValueOf(v))
 }
 
@@ -602,6 +662,11 @@ func encodeNamedValue(v driver.NamedValue, param *ast.ParameterNode) (sql.NamedA
 
 func valueLayoutFromValue(v Value) (*ValueLayout, error) {
 	switch vv := v.(type) {
+	case FloatValue:
+		return &ValueLayout{
+			Header: FloatValueType,
+			Body:   strconv.FormatFloat(float64(vv), 'g', -1, 64),
+		}, nil
 	case StringValue:
 		return &ValueLayout{
 			Header: StringValueType,
@@ -668,6 +733,32 @@ func valueLayoutFromValue(v Value) (*ValueLayout, error) {
 			Header: IntervalValueType,
 			Body:   s,
 		}, nil
+	case *GeographyValue:
+		s, err := vv.ToString()
+		if err != nil {
+			return nil, err
+		}
+		return &ValueLayout{
+			Header: GeographyValueType,
+			Body:   s,
+		}, nil
+	case *RangeValue:
+		boundaries := make([]interface{}, 0, 2)
+		for _, boundary := range []Value{vv.Start, vv.End} {
+			value, err := EncodeValue(boundary)
+			if err != nil {
+				return nil, err
+			}
+			boundaries = append(boundaries, value)
+		}
+		body, err := json.Marshal(boundaries)
+		if err != nil {
+			return nil, err
+		}
+		return &ValueLayout{
+			Header: RangeValueType,
+			Body:   string(body),
+		}, nil
 	case JsonValue:
 		return &ValueLayout{
 			Header: JsonValueType,
@@ -165,9 +165,12 @@ func getFuncNameAndArgs(ctx context.Context, node *ast.BaseFunctionCallNode, isW
 		}
 	} else if existsCurrentTimeFunc {
 		if currentTime != nil {
+			disableStmtCache(ctx)
+			// the bind functions expect the current time as the first argument,
+			// followed by the optional time zone argument.
 			args = append(
-				args,
-				fmt.Sprint(currentTime.UnixNano()),
+				[]string{fmt.Sprint(currentTime.UnixNano())},
+				args...,
 			)
 		}
 		funcName = fmt.Sprintf("%s_%s", funcPrefix, funcName)
@@ -337,6 +340,12 @@ func (n *AggregateFunctionCallNode) FormatSQL(ctx context.Context) (string, erro
 	), nil
 }
 
+var respectNullsByDefaultFuncMap = map[string]struct{}{
+	"first_value": {},
+	"last_value":  {},
+	"nth_value":   {},
+}
+
 func (n *AnalyticFunctionCallNode) FormatSQL(ctx context.Context) (string, error) {
 	if n.node == nil {
 		return "", nil
@@ -352,10 +361,15 @@ func (n *AnalyticFunctionCallNode) FormatSQL(ctx context.Context) (string, error
 		opts = append(opts, "zetasqlite_distinct()")
 	}
 	switch n.node.NullHandlingModifier() {
+	case ast.IgnoreNulls:
+		opts = append(opts, "zetasqlite_ignore_nulls()")
 	case ast.RespectNulls:
 		// do nothing
 	default:
-		opts = append(opts, "zetasqlite_ignore_nulls()")
+		// navigation functions respect NULLs unless IGNORE NULLS is specified.
+		if _, exists := respectNullsByDefaultFuncMap[n.node.Function().Name()]; !exists {
+			opts = append(opts, "zetasqlite_ignore_nulls()")
+		}
 	}
 	args = append(args, opts...)
 	for _, column := range analyticPartitionColumnNamesFromContext(ctx) {
@@ -1179,7 +1193,67 @@ func (n *AnalyticScanNode) FormatSQL(ctx context.Context) (string, error) {
 }
 
 func (n *SampleScanNode) FormatSQL(ctx context.Context) (string, error) {
-	return "", nil
+	if n.node == nil {
+		return "", nil
+	}
+	if n.node.WeightColumn() != nil || len(n.node.PartitionByList()) != 0 {
+		return "", fmt.Errorf("unsupported TABLESAMPLE with WITH WEIGHT or PARTITION BY")
+	}
+	input, err := newNode(n.node.InputScan()).FormatSQL(ctx)
+	if err != nil {
+		return "", err
+	}
+	size, err := newNode(n.node.Size()).FormatSQL(ctx)
+	if err != nil {
+		return "", err
+	}
+	// REPEATABLE argument takes precedence over the seed specified by WithRandSeed.
+	// If neither is specified, each row is sampled by a non-deterministic random number.
+	seed := "NULL"
+	if n.node.RepeatableArgument() != nil {
+		repeatable, err := newNode(n.node.RepeatableArgument()).FormatSQL(ctx)
+		if err != nil {
+			return "", err
+		}
+		seed = repeatable
+	} else if randSeed := randSeedFromContext(ctx); randSeed != nil {
+		disableStmtCache(ctx)
+		seed = fmt.Sprint(*randSeed)
+	}
+	columns := []string{}
+	columnMap := columnRefMap(ctx)
+	for _, col := range n.node.ColumnList() {
+		colName := uniqueColumnName(ctx, col)
+		if ref, exists := columnMap[colName]; exists {
+			columns = append(columns, ref)
+			delete(columnMap, colName)
+		} else {
+			columns = append(
+				columns,
+				fmt.Sprintf("`%s`", colName),
+			)
+		}
+	}
+	formattedInput, err := formatInput(input)
+	if err != nil {
+		return "", err
+	}
+	sampleRand := fmt.Sprintf("zetasqlite_sample_rand(%s, `row_id`)", seed)
+	var cond string
+	switch n.node.Unit() {
+	case ast.SampleUnitPercent:
+		cond = fmt.Sprintf("WHERE %s * 100 < %s", sampleRand, size)
+	case ast.SampleUnitRows:
+		cond = fmt.Sprintf("ORDER BY %s LIMIT %s", sampleRand, size)
+	default:
+		return "", fmt.Errorf("unexpected TABLESAMPLE unit %v", n.node.Unit())
+	}
+	return fmt.Sprintf(
+		"SELECT %s FROM (SELECT *, ROW_NUMBER() OVER() AS `row_id` %s) %s",
+		strings.Join(columns, ","),
+		formattedInput,
+		cond,
+	), nil
 }
 
 func (n *ComputedColumnNode) FormatSQL(ctx context.Context) (string, error) {
@@ -1536,7 +1610,8 @@ func (n *DMLValueNode) FormatSQL(ctx context.Context) (string, error) {
 }
 
 func (n *DMLDefaultNode) FormatSQL(ctx context.Context) (string, error) {
-	return "", nil
+	// column without default value is filled with NULL.
+	return "NULL", nil
 }
 
 func (n *AssertStmtNode) FormatSQL(ctx context.Context) (string, error) {
@@ -1586,13 +1661,22 @@ func (n *InsertStmtNode) FormatSQL(ctx context.Context) (string, error) {
 			stmt,
 		), nil
 	}
+	defaultValues := n.columnDefaultValues(ctx, table)
 	rows := []string{}
 	for _, row := range n.node.RowList() {
-		sql, err := newNode(row).FormatSQL(ctx)
-		if err != nil {
-			return "", err
+		values := []string{}
+		for idx, value := range row.ValueList() {
+			if _, ok := value.Value().(*ast.DMLDefaultNode); ok && defaultValues[idx] != "" {
+				values = append(values, defaultValues[idx])
+				continue
+			}
+			sql, err := newNode(value).FormatSQL(ctx)
+			if err != nil {
+				return "", err
+			}
+			values = append(values, sql)
 		}
-		rows = append(rows, fmt.Sprintf("(%s)", sql))
+		rows = append(rows, fmt.Sprintf("(%s)", strings.Join(values, ",")))
 	}
 	return fmt.Sprintf("INSERT INTO `%s` (%s) VALUES %s",
 		table,
@@ -1601,6 +1685,24 @@ func (n *InsertStmtNode) FormatSQL(ctx context.Context) (string, error) {
 	), nil
 }
 
+func (n *InsertStmtNode) columnDefaultValues(ctx context.Context, table string) []string {
+	defaultValues := make([]string, len(n.node.InsertColumnList()))
+	analyzer := analyzerFromContext(ctx)
+	if analyzer == nil {
+		return defaultValues
+	}
+	spec, exists := analyzer.catalog.lookupTableSpec(table)
+	if !exists {
+		return defaultValues
+	}
+	for idx, col := range n.node.InsertColumnList() {
+		if colSpec := spec.Column(col.Name()); colSpec != nil {
+			defaultValues[idx] = colSpec.DefaultValue
+		}
+	}
+	return defaultValues
+}
+
 func (n *DeleteStmtNode) FormatSQL(ctx context.Context) (string, error) {
 	if n == nil {
 		return "", nil
@@ -420,23 +420,25 @@ func MAKE_STRUCT(args ...Value) (Value, error) {
 func EXTRACT(v Value, part, zone string) (Value, error) {
 	switch vv := v.(type) {
 	case *IntervalValue:
+		// normalize overflowing fields ( e.g. MAKE_INTERVAL(month => 14) is 1 year 2 months ).
+		iv := vv.Canonicalize()
 		switch part {
 		case "YEAR":
-			return IntValue(vv.Years), nil
+			return IntValue(iv.Years), nil
 		case "MONTH":
-			return IntValue(vv.Months), nil
+			return IntValue(iv.Months), nil
 		case "DAY":
-			return IntValue(vv.Days), nil
+			return IntValue(iv.Days), nil
 		case "HOUR":
-			return IntValue(vv.Hours), nil
+			return IntValue(iv.Hours), nil
 		case "MINUTE":
-			return IntValue(vv.Minutes), nil
+			return IntValue(iv.Minutes), nil
 		case "SECOND":
-			return IntValue(vv.Seconds), nil
+			return IntValue(iv.Seconds), nil
 		case "MILLISECOND":
-			return IntValue(vv.SubSecondNanos / int32(time.Millisecond)), nil
+			return IntValue(iv.SubSecondNanos / int32(time.Millisecond)), nil
 		case "MICROSECOND":
-			return IntValue(vv.SubSecondNanos / int32(time.Microsecond)), nil
+			return IntValue(iv.SubSecondNanos / int32(time.Microsecond)), nil
 		}
 		return nil, fmt.Errorf("EXTRACT: unexpected part %s for interval", part)
 	case DateValue, DatetimeValue, TimeValue, TimestampValue:
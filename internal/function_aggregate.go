@@ -2,6 +2,7 @@ package internal
 
 import (
 	"fmt"
+	"math"
 	"sort"
 	"strings"
 	"sync"
@@ -141,15 +142,21 @@ func (f *ARRAY_CONCAT_AGG) Done() (Value, error) {
 }
 
 type AVG struct {
-	sum Value
-	num int64
+	sum      Value
+	floatSum *floatSum
+	num      int64
 }
 
 func (f *AVG) Step(v Value, opt *AggregatorOption) error {
 	if v == nil {
 		return nil
 	}
-	if f.sum == nil {
+	if fv, ok := v.(FloatValue); ok {
+		if f.floatSum == nil {
+			f.floatSum = &floatSum{}
+		}
+		f.floatSum.add(float64(fv))
+	} else if f.sum == nil {
 		f.sum = v
 	} else {
 		added, err := f.sum.Add(v)
@@ -163,6 +170,9 @@ func (f *AVG) Step(v Value, opt *AggregatorOption) error {
 }
 
 func (f *AVG) Done() (Value, error) {
+	if f.floatSum != nil {
+		return FloatValue(f.floatSum.value() / float64(f.num)), nil
+	}
 	if f.sum == nil {
 		return nil, nil
 	}
@@ -509,13 +519,21 @@ func (f *STRING_AGG) Done() (Value, error) {
 }
 
 type SUM struct {
-	sum Value
+	sum      Value
+	floatSum *floatSum
 }
 
 func (f *SUM) Step(v Value, opt *AggregatorOption) error {
 	if v == nil {
 		return nil
 	}
+	if fv, ok := v.(FloatValue); ok {
+		if f.floatSum == nil {
+			f.floatSum = &floatSum{}
+		}
+		f.floatSum.add(float64(fv))
+		return nil
+	}
 	if f.sum == nil {
 		f.sum = v
 	} else {
@@ -529,9 +547,39 @@ func (f *SUM) Step(v Value, opt *AggregatorOption) error {
 }
 
 func (f *SUM) Done() (Value, error) {
+	if f.floatSum != nil {
+		return FloatValue(f.floatSum.value()), nil
+	}
 	return f.sum, nil
 }
 
+// floatSum accumulates FLOAT64 values with compensated ( Kahan-Babuska ) summation
+// so that the rounding error does not grow with the number of values.
+type floatSum struct {
+	sum          float64
+	compensation float64
+}
+
+func (s *floatSum) add(v float64) {
+	t := s.sum + v
+	if math.IsInf(t, 0) || math.IsNaN(t) {
+		// the compensation is meaningless for non-finite values.
+		s.sum = t
+		s.compensation = 0
+		return
+	}
+	if math.Abs(s.sum) >= math.Abs(v) {
+		s.compensation += (s.sum - t) + v
+	} else {
+		s.compensation += (v - t) + s.sum
+	}
+	s.sum = t
+}
+
+func (s *floatSum) value() float64 {
+	return s.sum + s.compensation
+}
+
 type CORR struct {
 	x []float64
 	y []float64
@@ -188,15 +188,19 @@ func generateDateArray(start, end Value, step int, interval string) (Value, erro
 		// start greater than end and step is positive value
 		return arr, nil
 	}
-	cur := start
-	for {
-		arr.values = append(arr.values, cur)
-		after, err := cur.(DateValue).AddDateWithInterval(step, interval)
+	startDate, ok := start.(DateValue)
+	if !ok {
+		return nil, fmt.Errorf("GENERATE_DATE_ARRAY: unexpected start value type %T", start)
+	}
+	// each element is computed from the start date so that
+	// the day clamped at the end of a month doesn't affect the following elements.
+	for i := 0; ; i++ {
+		cur, err := startDate.AddDateWithInterval(step*i, interval)
 		if err != nil {
 			return nil, err
 		}
 		if isLT {
-			cond, err := after.LTE(end)
+			cond, err := cur.LTE(end)
 			if err != nil {
 				return nil, err
 			}
@@ -204,7 +208,7 @@ func generateDateArray(start, end Value, step int, interval string) (Value, erro
 				break
 			}
 		} else {
-			cond, err := after.GTE(end)
+			cond, err := cur.GTE(end)
 			if err != nil {
 				return nil, err
 			}
@@ -212,7 +216,7 @@ func generateDateArray(start, end Value, step int, interval string) (Value, erro
 				break
 			}
 		}
-		cur = after
+		arr.values = append(arr.values, cur)
 	}
 	return arr, nil
 }
@@ -224,3 +228,187 @@ func ARRAY_REVERSE(v *ArrayValue) (Value, error) {
 	}
 	return ret, nil
 }
+
+func ARRAY_FIRST(v *ArrayValue) (Value, error) {
+	if len(v.values) == 0 {
+		return nil, fmt.Errorf("ARRAY_FIRST: cannot get the first element of an empty array")
+	}
+	return v.values[0], nil
+}
+
+func ARRAY_LAST(v *ArrayValue) (Value, error) {
+	if len(v.values) == 0 {
+		return nil, fmt.Errorf("ARRAY_LAST: cannot get the last element of an empty array")
+	}
+	return v.values[len(v.values)-1], nil
+}
+
+func ARRAY_IS_DISTINCT(v *ArrayValue) (Value, error) {
+	var (
+		existsNil bool
+		valueMap  = map[string]struct{}{}
+	)
+	for _, elem := range v.values {
+		if elem == nil {
+			if existsNil {
+				return BoolValue(false), nil
+			}
+			existsNil = true
+			continue
+		}
+		key, err := elem.ToString()
+		if err != nil {
+			return nil, err
+		}
+		if _, exists := valueMap[key]; exists {
+			return BoolValue(false), nil
+		}
+		valueMap[key] = struct{}{}
+	}
+	return BoolValue(true), nil
+}
+
+func ARRAY_INCLUDES(v *ArrayValue, target Value) (Value, error) {
+	return IN(target, v.values...)
+}
+
+func ARRAY_INCLUDES_ANY(v *ArrayValue, search *ArrayValue) (Value, error) {
+	for _, target := range search.values {
+		if target == nil {
+			continue
+		}
+		found, err := arrayIncludes(v, target)
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			return BoolValue(true), nil
+		}
+	}
+	return BoolValue(false), nil
+}
+
+func ARRAY_INCLUDES_ALL(v *ArrayValue, search *ArrayValue) (Value, error) {
+	for _, target := range search.values {
+		if target == nil {
+			return BoolValue(false), nil
+		}
+		found, err := arrayIncludes(v, target)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			return BoolValue(false), nil
+		}
+	}
+	return BoolValue(true), nil
+}
+
+func arrayIncludes(v *ArrayValue, target Value) (bool, error) {
+	found, err := IN(target, v.values...)
+	if err != nil {
+		return false, err
+	}
+	return found.ToBool()
+}
+
+func ARRAY_MIN(v *ArrayValue) (Value, error) {
+	agg := &MIN{}
+	for _, elem := range v.values {
+		if err := agg.Step(elem, nil); err != nil {
+			return nil, err
+		}
+	}
+	return agg.Done()
+}
+
+func ARRAY_MAX(v *ArrayValue) (Value, error) {
+	agg := &MAX{}
+	for _, elem := range v.values {
+		if err := agg.Step(elem, nil); err != nil {
+			return nil, err
+		}
+	}
+	return agg.Done()
+}
+
+func ARRAY_SUM(v *ArrayValue) (Value, error) {
+	var sum Value
+	for _, elem := range v.values {
+		if elem == nil {
+			continue
+		}
+		if !isNumericArrayElement(elem) {
+			return nil, fmt.Errorf("ARRAY_SUM: unsupported element type %T", elem)
+		}
+		if sum == nil {
+			sum = elem
+			continue
+		}
+		if x, ok := sum.(IntValue); ok {
+			if y, ok := elem.(IntValue); ok {
+				added := x + y
+				if (y > 0 && added < x) || (y < 0 && added > x) {
+					return nil, fmt.Errorf("ARRAY_SUM: int64 overflow: %d + %d", x, y)
+				}
+				sum = added
+				continue
+			}
+		}
+		added, err := sum.Add(elem)
+		if err != nil {
+			return nil, err
+		}
+		sum = added
+	}
+	return sum, nil
+}
+
+func ARRAY_AVG(v *ArrayValue) (Value, error) {
+	var (
+		sum Value
+		num int64
+	)
+	for _, elem := range v.values {
+		if elem == nil {
+			continue
+		}
+		if !isNumericArrayElement(elem) {
+			return nil, fmt.Errorf("ARRAY_AVG: unsupported element type %T", elem)
+		}
+		if iv, ok := elem.(IntValue); ok {
+			// the average of INT64 is FLOAT64, so the sum is also computed by FLOAT64 to avoid the overflow.
+			elem = FloatValue(iv)
+		}
+		num++
+		if sum == nil {
+			sum = elem
+			continue
+		}
+		added, err := sum.Add(elem)
+		if err != nil {
+			return nil, err
+		}
+		sum = added
+	}
+	if sum == nil {
+		return nil, nil
+	}
+	if _, ok := sum.(*NumericValue); ok {
+		// the average of NUMERIC and BIGNUMERIC keeps its type.
+		return sum.Div(IntValue(num))
+	}
+	f64, err := sum.ToFloat64()
+	if err != nil {
+		return nil, err
+	}
+	return FloatValue(f64 / float64(num)), nil
+}
+
+func isNumericArrayElement(v Value) bool {
+	switch v.(type) {
+	case IntValue, FloatValue, *NumericValue:
+		return true
+	}
+	return false
+}
@@ -62,18 +62,10 @@ func (a *Aggregator) Step(stepArgs ...interface{}) error {
 		return err
 	}
 	values, opt := parseAggregateOptions(values...)
-	if opt.IgnoreNulls {
-		filtered := []Value{}
-		for _, v := range values {
-			if v == nil {
-				continue
-			}
-			filtered = append(filtered, v)
-		}
-		values = filtered
-		if len(values) == 0 {
-			return nil
-		}
+	// NULL values must be skipped before they are keyed by DISTINCT,
+	// so that DISTINCT with IGNORE NULLS never keeps a NULL value.
+	if opt.IgnoreNulls && (len(values) == 0 || values[0] == nil) {
+		return nil
 	}
 	if opt.Distinct {
 		if len(values) < 1 {
@@ -543,6 +535,12 @@ func bindInterval(args ...Value) (Value, error) {
 }
 
 func bindMakeInterval(args ...Value) (Value, error) {
+	if len(args) != 6 {
+		return nil, fmt.Errorf("MAKE_INTERVAL: invalid argument num %d", len(args))
+	}
+	if existsNull(args) {
+		return nil, nil
+	}
 	year, err := args[0].ToInt64()
 	if err != nil {
 		return nil, err
@@ -595,6 +593,12 @@ func bindJustifyInterval(args ...Value) (Value, error) {
 }
 
 func bindParseNumeric(args ...Value) (Value, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("PARSE_NUMERIC: invalid argument num %d", len(args))
+	}
+	if existsNull(args) {
+		return nil, nil
+	}
 	numeric, err := args[0].ToString()
 	if err != nil {
 		return nil, err
@@ -603,6 +607,12 @@ func bindParseNumeric(args ...Value) (Value, error) {
 }
 
 func bindParseBigNumeric(args ...Value) (Value, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("PARSE_BIGNUMERIC: invalid argument num %d", len(args))
+	}
+	if existsNull(args) {
+		return nil, nil
+	}
 	numeric, err := args[0].ToString()
 	if err != nil {
 		return nil, err
@@ -826,7 +836,7 @@ func bindFormat(args ...Value) (Value, error) {
 	if len(args) == 0 {
 		return nil, fmt.Errorf("FORMAT: invalid argument num %d", len(args))
 	}
-	if existsNull(args) {
+	if args[0] == nil {
 		return nil, nil
 	}
 	format, err := args[0].ToString()
@@ -1553,31 +1563,46 @@ func bindBool(args ...Value) (Value, error) {
 	if len(args) != 1 {
 		return nil, fmt.Errorf("BOOL: invalid argument num %d", len(args))
 	}
-	return args[0], nil
+	if existsNull(args) {
+		return nil, nil
+	}
+	value, ok := args[0].(JsonValue)
+	if !ok {
+		return nil, fmt.Errorf("BOOL: failed to convert %T to JSON value", args[0])
+	}
+	return JSON_BOOL(value)
 }
 
 func bindInt64(args ...Value) (Value, error) {
 	if len(args) != 1 {
 		return nil, fmt.Errorf("INT64: invalid argument num %d", len(args))
 	}
-	return args[0], nil
+	if existsNull(args) {
+		return nil, nil
+	}
+	value, ok := args[0].(JsonValue)
+	if !ok {
+		return nil, fmt.Errorf("INT64: failed to convert %T to JSON value", args[0])
+	}
+	return JSON_INT64(value)
 }
 
 func bindDouble(args ...Value) (Value, error) {
 	if len(args) != 2 {
 		return nil, fmt.Errorf("FLOAT64: invalid argument num %d", len(args))
 	}
+	if existsNull(args) {
+		return nil, nil
+	}
+	value, ok := args[0].(JsonValue)
+	if !ok {
+		return nil, fmt.Errorf("FLOAT64: failed to convert %T to JSON value", args[0])
+	}
 	mode, err := args[1].ToString()
 	if err != nil {
 		return nil, err
 	}
-	switch mode {
-	case "exact":
-		return args[0], nil
-	case "round":
-		return args[0], nil
-	}
-	return nil, fmt.Errorf("unexpected wide_number_mode: %s", mode)
+	return JSON_FLOAT64(value, mode)
 }
 
 func bindJsonType(args ...Value) (Value, error) {
@@ -1633,6 +1658,13 @@ func bindRand(args ...Value) (Value, error) {
 	return RAND()
 }
 
+func bindSampleRand(args ...Value) (Value, error) {
+	if len(args) != 2 {
+		return nil, fmt.Errorf("SAMPLE_RAND: invalid argument num %d", len(args))
+	}
+	return SAMPLE_RAND(args[0], args[1])
+}
+
 func bindSqrt(args ...Value) (Value, error) {
 	if existsNull(args) {
 		return nil, nil
@@ -1640,6 +1672,13 @@ func bindSqrt(args ...Value) (Value, error) {
 	return SQRT(args[0])
 }
 
+func bindCbrt(args ...Value) (Value, error) {
+	if existsNull(args) {
+		return nil, nil
+	}
+	return CBRT(args[0])
+}
+
 func bindPow(args ...Value) (Value, error) {
 	if existsNull(args) {
 		return nil, nil
@@ -1745,6 +1784,9 @@ func bindRound(args ...Value) (Value, error) {
 	if len(args) != 1 && len(args) != 2 {
 		return nil, fmt.Errorf("ROUND: invalid argument num %d", len(args))
 	}
+	if existsNull(args) {
+		return nil, nil
+	}
 	var precision int = 0
 	if len(args) == 2 {
 		i64, err := args[1].ToInt64()
@@ -1753,22 +1795,31 @@ func bindRound(args ...Value) (Value, error) {
 		}
 		precision = int(i64)
 	}
-
-	if existsNull(args) {
-		return nil, nil
-	}
-
 	return ROUND(args[0], precision)
 }
 
 func bindTrunc(args ...Value) (Value, error) {
+	if len(args) != 1 && len(args) != 2 {
+		return nil, fmt.Errorf("TRUNC: invalid argument num %d", len(args))
+	}
 	if existsNull(args) {
 		return nil, nil
 	}
-	return TRUNC(args[0])
+	var precision int
+	if len(args) == 2 {
+		i64, err := args[1].ToInt64()
+		if err != nil {
+			return nil, err
+		}
+		precision = int(i64)
+	}
+	return TRUNC(args[0], precision)
 }
 
 func bindCeil(args ...Value) (Value, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("CEIL: invalid argument num %d", len(args))
+	}
 	if existsNull(args) {
 		return nil, nil
 	}
@@ -1776,6 +1827,9 @@ func bindCeil(args ...Value) (Value, error) {
 }
 
 func bindFloor(args ...Value) (Value, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("FLOOR: invalid argument num %d", len(args))
+	}
 	if existsNull(args) {
 		return nil, nil
 	}
@@ -1873,6 +1927,48 @@ func bindAtan2(args ...Value) (Value, error) {
 	return ATAN2(args[0], args[1])
 }
 
+func bindCot(args ...Value) (Value, error) {
+	if existsNull(args) {
+		return nil, nil
+	}
+	return COT(args[0])
+}
+
+func bindCoth(args ...Value) (Value, error) {
+	if existsNull(args) {
+		return nil, nil
+	}
+	return COTH(args[0])
+}
+
+func bindCsc(args ...Value) (Value, error) {
+	if existsNull(args) {
+		return nil, nil
+	}
+	return CSC(args[0])
+}
+
+func bindCsch(args ...Value) (Value, error) {
+	if existsNull(args) {
+		return nil, nil
+	}
+	return CSCH(args[0])
+}
+
+func bindSec(args ...Value) (Value, error) {
+	if existsNull(args) {
+		return nil, nil
+	}
+	return SEC(args[0])
+}
+
+func bindSech(args ...Value) (Value, error) {
+	if existsNull(args) {
+		return nil, nil
+	}
+	return SECH(args[0])
+}
+
 func bindRangeBucket(args ...Value) (Value, error) {
 	if existsNull(args) {
 		return nil, nil
@@ -2011,6 +2107,31 @@ func bindDateTrunc(args ...Value) (Value, error) {
 	return DATE_TRUNC(t, part)
 }
 
+func bindDateBucket(args ...Value) (Value, error) {
+	if len(args) != 2 && len(args) != 3 {
+		return nil, fmt.Errorf("DATE_BUCKET: invalid argument num %d", len(args))
+	}
+	if existsNull(args) {
+		return nil, nil
+	}
+	t, err := args[0].ToTime()
+	if err != nil {
+		return nil, err
+	}
+	width, ok := args[1].(*IntervalValue)
+	if !ok {
+		return nil, fmt.Errorf("DATE_BUCKET: unexpected bucket width type %T", args[1])
+	}
+	origin := defaultBucketOrigin
+	if len(args) == 3 {
+		origin, err = args[2].ToTime()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return DATE_BUCKET(t, width, origin)
+}
+
 func bindDateFromUnixDate(args ...Value) (Value, error) {
 	if len(args) != 1 {
 		return nil, fmt.Errorf("DATE_FROM_UNIX_DATE: invalid argument num %d", len(args))
@@ -2206,6 +2327,31 @@ func bindDatetimeDiff(args ...Value) (Value, error) {
 	return DATETIME_DIFF(t, t2, part)
 }
 
+func bindDatetimeBucket(args ...Value) (Value, error) {
+	if len(args) != 2 && len(args) != 3 {
+		return nil, fmt.Errorf("DATETIME_BUCKET: invalid argument num %d", len(args))
+	}
+	if existsNull(args) {
+		return nil, nil
+	}
+	t, err := args[0].ToTime()
+	if err != nil {
+		return nil, err
+	}
+	width, ok := args[1].(*IntervalValue)
+	if !ok {
+		return nil, fmt.Errorf("DATETIME_BUCKET: unexpected bucket width type %T", args[1])
+	}
+	origin := defaultBucketOrigin
+	if len(args) == 3 {
+		origin, err = args[2].ToTime()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return DATETIME_BUCKET(t, width, origin)
+}
+
 func bindDatetimeTrunc(args ...Value) (Value, error) {
 	if len(args) != 2 {
 		return nil, fmt.Errorf("DATETIME_TRUNC: invalid argument num %d", len(args))
@@ -2468,7 +2614,7 @@ func bindString(args ...Value) (Value, error) {
 	}
 	jsonValue, ok := args[0].(JsonValue)
 	if ok {
-		return StringValue(fmt.Sprint(jsonValue.Interface())), nil
+		return JSON_STRING(jsonValue)
 	}
 	t, err := args[0].ToTime()
 	if err != nil {
@@ -2569,6 +2715,31 @@ func bindTimestampDiff(args ...Value) (Value, error) {
 	return TIMESTAMP_DIFF(t, t2, part)
 }
 
+func bindTimestampBucket(args ...Value) (Value, error) {
+	if len(args) != 2 && len(args) != 3 {
+		return nil, fmt.Errorf("TIMESTAMP_BUCKET: invalid argument num %d", len(args))
+	}
+	if existsNull(args) {
+		return nil, nil
+	}
+	t, err := args[0].ToTime()
+	if err != nil {
+		return nil, err
+	}
+	width, ok := args[1].(*IntervalValue)
+	if !ok {
+		return nil, fmt.Errorf("TIMESTAMP_BUCKET: unexpected bucket width type %T", args[1])
+	}
+	origin := defaultBucketOrigin
+	if len(args) == 3 {
+		origin, err = args[2].ToTime()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return TIMESTAMP_BUCKET(t, width, origin)
+}
+
 func bindTimestampTrunc(args ...Value) (Value, error) {
 	if len(args) != 2 && len(args) != 3 {
 		return nil, fmt.Errorf("TIMESTAMP_TRUNC: invalid argument num %d", len(args))
@@ -2731,6 +2902,9 @@ func bindArrayConcat(args ...Value) (Value, error) {
 	if len(args) == 0 {
 		return nil, fmt.Errorf("ARRAY_CONCAT: required arguments")
 	}
+	if existsNull(args) {
+		return nil, nil
+	}
 	return ARRAY_CONCAT(args...)
 }
 
@@ -2738,6 +2912,9 @@ func bindArrayLength(args ...Value) (Value, error) {
 	if len(args) != 1 {
 		return nil, fmt.Errorf("ARRAY_LENGTH: invalid argument num %d", len(args))
 	}
+	if existsNull(args) {
+		return nil, nil
+	}
 	arr, err := args[0].ToArray()
 	if err != nil {
 		return nil, err
@@ -2802,10 +2979,35 @@ func bindGenerateTimestampArray(args ...Value) (Value, error) {
 	return GENERATE_TIMESTAMP_ARRAY(args[0], args[1], step, part)
 }
 
+func bindGenerateRangeArray(args ...Value) (Value, error) {
+	if len(args) != 2 && len(args) != 3 {
+		return nil, fmt.Errorf("GENERATE_RANGE_ARRAY: invalid argument num %d", len(args))
+	}
+	if existsNull(args) {
+		return nil, nil
+	}
+	step, ok := args[1].(*IntervalValue)
+	if !ok {
+		return nil, fmt.Errorf("GENERATE_RANGE_ARRAY: step must be INTERVAL type but got %T", args[1])
+	}
+	includeLastPartialRange := true
+	if len(args) == 3 {
+		b, err := args[2].ToBool()
+		if err != nil {
+			return nil, err
+		}
+		includeLastPartialRange = b
+	}
+	return GENERATE_RANGE_ARRAY(args[0], step, includeLastPartialRange)
+}
+
 func bindArrayReverse(args ...Value) (Value, error) {
 	if len(args) != 1 {
 		return nil, fmt.Errorf("ARRAY_REVERSE: invalid argument num %d", len(args))
 	}
+	if existsNull(args) {
+		return nil, nil
+	}
 	arr, err := args[0].ToArray()
 	if err != nil {
 		return nil, err
@@ -2813,6 +3015,154 @@ func bindArrayReverse(args ...Value) (Value, error) {
 	return ARRAY_REVERSE(arr)
 }
 
+func bindArrayFirst(args ...Value) (Value, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("ARRAY_FIRST: invalid argument num %d", len(args))
+	}
+	if existsNull(args) {
+		return nil, nil
+	}
+	arr, err := args[0].ToArray()
+	if err != nil {
+		return nil, err
+	}
+	return ARRAY_FIRST(arr)
+}
+
+func bindArrayLast(args ...Value) (Value, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("ARRAY_LAST: invalid argument num %d", len(args))
+	}
+	if existsNull(args) {
+		return nil, nil
+	}
+	arr, err := args[0].ToArray()
+	if err != nil {
+		return nil, err
+	}
+	return ARRAY_LAST(arr)
+}
+
+func bindArrayIsDistinct(args ...Value) (Value, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("ARRAY_IS_DISTINCT: invalid argument num %d", len(args))
+	}
+	if existsNull(args) {
+		return nil, nil
+	}
+	arr, err := args[0].ToArray()
+	if err != nil {
+		return nil, err
+	}
+	return ARRAY_IS_DISTINCT(arr)
+}
+
+func bindArrayIncludes(args ...Value) (Value, error) {
+	if len(args) != 2 {
+		return nil, fmt.Errorf("ARRAY_INCLUDES: invalid argument num %d", len(args))
+	}
+	if existsNull(args) {
+		return nil, nil
+	}
+	arr, err := args[0].ToArray()
+	if err != nil {
+		return nil, err
+	}
+	return ARRAY_INCLUDES(arr, args[1])
+}
+
+func bindArrayIncludesAny(args ...Value) (Value, error) {
+	if len(args) != 2 {
+		return nil, fmt.Errorf("ARRAY_INCLUDES_ANY: invalid argument num %d", len(args))
+	}
+	if existsNull(args) {
+		return nil, nil
+	}
+	arr, err := args[0].ToArray()
+	if err != nil {
+		return nil, err
+	}
+	search, err := args[1].ToArray()
+	if err != nil {
+		return nil, err
+	}
+	return ARRAY_INCLUDES_ANY(arr, search)
+}
+
+func bindArrayIncludesAll(args ...Value) (Value, error) {
+	if len(args) != 2 {
+		return nil, fmt.Errorf("ARRAY_INCLUDES_ALL: invalid argument num %d", len(args))
+	}
+	if existsNull(args) {
+		return nil, nil
+	}
+	arr, err := args[0].ToArray()
+	if err != nil {
+		return nil, err
+	}
+	search, err := args[1].ToArray()
+	if err != nil {
+		return nil, err
+	}
+	return ARRAY_INCLUDES_ALL(arr, search)
+}
+
+func bindArrayMin(args ...Value) (Value, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("ARRAY_MIN: invalid argument num %d", len(args))
+	}
+	if existsNull(args) {
+		return nil, nil
+	}
+	arr, err := args[0].ToArray()
+	if err != nil {
+		return nil, err
+	}
+	return ARRAY_MIN(arr)
+}
+
+func bindArrayMax(args ...Value) (Value, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("ARRAY_MAX: invalid argument num %d", len(args))
+	}
+	if existsNull(args) {
+		return nil, nil
+	}
+	arr, err := args[0].ToArray()
+	if err != nil {
+		return nil, err
+	}
+	return ARRAY_MAX(arr)
+}
+
+func bindArraySum(args ...Value) (Value, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("ARRAY_SUM: invalid argument num %d", len(args))
+	}
+	if existsNull(args) {
+		return nil, nil
+	}
+	arr, err := args[0].ToArray()
+	if err != nil {
+		return nil, err
+	}
+	return ARRAY_SUM(arr)
+}
+
+func bindArrayAvg(args ...Value) (Value, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("ARRAY_AVG: invalid argument num %d", len(args))
+	}
+	if existsNull(args) {
+		return nil, nil
+	}
+	arr, err := args[0].ToArray()
+	if err != nil {
+		return nil, err
+	}
+	return ARRAY_AVG(arr)
+}
+
 func bindMakeArray(args ...Value) (Value, error) {
 	return MAKE_ARRAY(args...)
 }
@@ -3091,6 +3441,175 @@ func bindNetSafeIpFromString(args ...Value) (Value, error) {
 	return NET_SAFE_IP_FROM_STRING(v)
 }
 
+func bindStGeogpoint(args ...Value) (Value, error) {
+	if len(args) != 2 {
+		return nil, fmt.Errorf("ST_GEOGPOINT: invalid argument num %d", len(args))
+	}
+	if existsNull(args) {
+		return nil, nil
+	}
+	lng, err := args[0].ToFloat64()
+	if err != nil {
+		return nil, err
+	}
+	lat, err := args[1].ToFloat64()
+	if err != nil {
+		return nil, err
+	}
+	return ST_GEOGPOINT(lng, lat)
+}
+
+func bindStGeogfromtext(args ...Value) (Value, error) {
+	if len(args) < 1 || len(args) > 4 {
+		return nil, fmt.Errorf("ST_GEOGFROMTEXT: invalid argument num %d", len(args))
+	}
+	if existsNull(args) {
+		return nil, nil
+	}
+	for idx, name := range []string{"oriented", "planar", "make_valid"} {
+		if idx+1 >= len(args) {
+			break
+		}
+		enabled, err := args[idx+1].ToBool()
+		if err != nil {
+			return nil, err
+		}
+		if enabled {
+			return nil, fmt.Errorf("ST_GEOGFROMTEXT: %s=TRUE is not supported", name)
+		}
+	}
+	wkt, err := args[0].ToString()
+	if err != nil {
+		return nil, err
+	}
+	return ST_GEOGFROMTEXT(wkt)
+}
+
+func bindStAstext(args ...Value) (Value, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("ST_ASTEXT: invalid argument num %d", len(args))
+	}
+	if existsNull(args) {
+		return nil, nil
+	}
+	return ST_ASTEXT(args[0])
+}
+
+func bindStDistance(args ...Value) (Value, error) {
+	if len(args) != 2 && len(args) != 3 {
+		return nil, fmt.Errorf("ST_DISTANCE: invalid argument num %d", len(args))
+	}
+	if existsNull(args) {
+		return nil, nil
+	}
+	if len(args) == 3 {
+		useSpheroid, err := args[2].ToBool()
+		if err != nil {
+			return nil, err
+		}
+		if useSpheroid {
+			return nil, fmt.Errorf("ST_DISTANCE: use_spheroid=TRUE is not supported")
+		}
+	}
+	a, ok := args[0].(*GeographyValue)
+	if !ok {
+		return nil, fmt.Errorf("ST_DISTANCE: unexpected argument type %T", args[0])
+	}
+	b, ok := args[1].(*GeographyValue)
+	if !ok {
+		return nil, fmt.Errorf("ST_DISTANCE: unexpected argument type %T", args[1])
+	}
+	return ST_DISTANCE(a, b)
+}
+
+func bindStDwithin(args ...Value) (Value, error) {
+	if len(args) != 3 && len(args) != 4 {
+		return nil, fmt.Errorf("ST_DWITHIN: invalid argument num %d", len(args))
+	}
+	if existsNull(args) {
+		return nil, nil
+	}
+	if len(args) == 4 {
+		useSpheroid, err := args[3].ToBool()
+		if err != nil {
+			return nil, err
+		}
+		if useSpheroid {
+			return nil, fmt.Errorf("ST_DWITHIN: use_spheroid=TRUE is not supported")
+		}
+	}
+	a, ok := args[0].(*GeographyValue)
+	if !ok {
+		return nil, fmt.Errorf("ST_DWITHIN: unexpected argument type %T", args[0])
+	}
+	b, ok := args[1].(*GeographyValue)
+	if !ok {
+		return nil, fmt.Errorf("ST_DWITHIN: unexpected argument type %T", args[1])
+	}
+	distance, err := args[2].ToFloat64()
+	if err != nil {
+		return nil, err
+	}
+	return ST_DWITHIN(a, b, distance)
+}
+
+func bindRange(args ...Value) (Value, error) {
+	if len(args) != 2 {
+		return nil, fmt.Errorf("RANGE: invalid argument num %d", len(args))
+	}
+	return RANGE(args[0], args[1])
+}
+
+func bindRangeStart(args ...Value) (Value, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("RANGE_START: invalid argument num %d", len(args))
+	}
+	if existsNull(args) {
+		return nil, nil
+	}
+	return RANGE_START(args[0])
+}
+
+func bindRangeEnd(args ...Value) (Value, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("RANGE_END: invalid argument num %d", len(args))
+	}
+	if existsNull(args) {
+		return nil, nil
+	}
+	return RANGE_END(args[0])
+}
+
+func bindRangeContains(args ...Value) (Value, error) {
+	if len(args) != 2 {
+		return nil, fmt.Errorf("RANGE_CONTAINS: invalid argument num %d", len(args))
+	}
+	if existsNull(args) {
+		return nil, nil
+	}
+	return RANGE_CONTAINS(args[0], args[1])
+}
+
+func bindRangeOverlaps(args ...Value) (Value, error) {
+	if len(args) != 2 {
+		return nil, fmt.Errorf("RANGE_OVERLAPS: invalid argument num %d", len(args))
+	}
+	if existsNull(args) {
+		return nil, nil
+	}
+	return RANGE_OVERLAPS(args[0], args[1])
+}
+
+func bindRangeIntersect(args ...Value) (Value, error) {
+	if len(args) != 2 {
+		return nil, fmt.Errorf("RANGE_INTERSECT: invalid argument num %d", len(args))
+	}
+	if existsNull(args) {
+		return nil, nil
+	}
+	return RANGE_INTERSECT(args[0], args[1])
+}
+
 func bindArray() func() *Aggregator {
 	return func() *Aggregator {
 		fn := &ARRAY{}
@@ -0,0 +1,169 @@
+package internal
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/goccy/go-zetasql/types"
+)
+
+// AggregateFunction is the interface implemented by the aggregate functions registered by RegisterAggregateFunction.
+// A new instance is created for each group and Step is called with the arguments of each row.
+// DISTINCT and IGNORE NULLS are applied before Step is called.
+type AggregateFunction interface {
+	Step(args ...Value) error
+	Done() (Value, error)
+}
+
+// customFunction is a function defined by the user of zetasqlite with Go code.
+// It is registered to the catalog in the same way as extraBuiltinFunction.
+type customFunction struct {
+	name       string
+	mode       types.Mode
+	returnType types.TypeKind
+}
+
+var (
+	customFunctions      []*customFunction
+	customFunctionNameRe = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+	builtinFunctionNamesOnce sync.Once
+	builtinFunctionNameMap   map[string]struct{}
+)
+
+// RegisterScalarFunction registers fn as the scalar function called by name.
+// The function accepts any number of arguments of any type including NULL ( passed as nil ),
+// and its result is converted to returnType.
+func RegisterScalarFunction(name string, returnType types.TypeKind, fn BindFunction) error {
+	name = strings.ToLower(name)
+	retType, err := customFunctionReturnType(name, returnType)
+	if err != nil {
+		return err
+	}
+	funcMapMu.Lock()
+	defer funcMapMu.Unlock()
+	if err := validateCustomFunctionName(name); err != nil {
+		return err
+	}
+	setupNormalFuncMap(&FuncInfo{
+		Name: name,
+		BindFunc: func(args ...Value) (Value, error) {
+			ret, err := fn(args...)
+			if err != nil {
+				return nil, err
+			}
+			return CastValue(retType, ret)
+		},
+	})
+	customFunctions = append(customFunctions, &customFunction{
+		name:       name,
+		mode:       types.ScalarMode,
+		returnType: returnType,
+	})
+	return nil
+}
+
+// RegisterAggregateFunction registers the aggregate function called by name. newFn is called to create AggregateFunction for each group.
+// The function accepts any number of arguments of any type, and its result is converted to returnType.
+func RegisterAggregateFunction(name string, returnType types.TypeKind, newFn func() AggregateFunction) error {
+	name = strings.ToLower(name)
+	retType, err := customFunctionReturnType(name, returnType)
+	if err != nil {
+		return err
+	}
+	funcMapMu.Lock()
+	defer funcMapMu.Unlock()
+	if err := validateCustomFunctionName(name); err != nil {
+		return err
+	}
+	setupAggregateFuncMap(&AggregateFuncInfo{
+		Name: name,
+		BindFunc: func() func() *Aggregator {
+			return func() *Aggregator {
+				fn := newFn()
+				return newAggregator(
+					func(args []Value, opt *AggregatorOption) error {
+						return fn.Step(args...)
+					},
+					func() (Value, error) {
+						ret, err := fn.Done()
+						if err != nil {
+							return nil, err
+						}
+						return CastValue(retType, ret)
+					},
+				)
+			}
+		},
+	})
+	customFunctions = append(customFunctions, &customFunction{
+		name:       name,
+		mode:       types.AggregateMode,
+		returnType: returnType,
+	})
+	return nil
+}
+
+func customFunctionReturnType(name string, kind types.TypeKind) (types.Type, error) {
+	switch kind {
+	case types.UNKNOWN, types.ENUM, types.ARRAY, types.STRUCT, types.PROTO, types.EXTENDED:
+		return nil, fmt.Errorf("failed to register function %s: unsupported return type %s", name, kind)
+	}
+	return types.TypeFromKind(kind), nil
+}
+
+func validateCustomFunctionName(name string) error {
+	if !customFunctionNameRe.MatchString(name) {
+		return fmt.Errorf("failed to register function %s: invalid function name", name)
+	}
+	for _, fn := range customFunctions {
+		if fn.name == name {
+			return fmt.Errorf("failed to register function %s: function is already registered", name)
+		}
+	}
+	if _, exists := builtinFunctionNames()[name]; exists {
+		return fmt.Errorf("failed to register function %s: function is already defined as builtin function", name)
+	}
+	return nil
+}
+
+// builtinFunctionNames returns the lower-case names of the functions implemented by zetasqlite and
+// defined in the catalog. The names are collected once because building the builtin catalog is expensive.
+func builtinFunctionNames() map[string]struct{} {
+	builtinFunctionNamesOnce.Do(func() {
+		builtinFunctionNameMap = map[string]struct{}{}
+		for _, info := range normalFuncs {
+			builtinFunctionNameMap[info.Name] = struct{}{}
+		}
+		for _, info := range aggregateFuncs {
+			builtinFunctionNameMap[info.Name] = struct{}{}
+		}
+		for _, info := range windowFuncs {
+			builtinFunctionNameMap[info.Name] = struct{}{}
+		}
+		cat := types.NewSimpleCatalog(catalogName)
+		cat.AddZetaSQLBuiltinFunctions(nil)
+		addExtraBuiltinFunctions(cat)
+		for _, name := range cat.FunctionNames() {
+			builtinFunctionNameMap[strings.ToLower(name)] = struct{}{}
+		}
+	})
+	return builtinFunctionNameMap
+}
+
+func addCustomFunctions(cat *types.SimpleCatalog) {
+	funcMapMu.RLock()
+	defer funcMapMu.RUnlock()
+	for _, fn := range customFunctions {
+		sig := newSignature(
+			fixedArgType(types.TypeFromKind(fn.returnType)),
+			types.NewTemplatedFunctionArgumentType(
+				types.ArgTypeArbitrary,
+				types.NewFunctionArgumentTypeOptions(types.RepeatedArgumentCardinality),
+			),
+		)
+		cat.AddFunction(types.NewFunction([]string{fn.name}, "", fn.mode, []*types.FunctionSignature{sig}))
+	}
+}
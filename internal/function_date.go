@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"strings"
 	"time"
+
+	"cloud.google.com/go/bigquery"
 )
 
 func CURRENT_DATE(zone string) (Value, error) {
@@ -67,7 +69,7 @@ func DATE_ADD(t time.Time, v int64, part string) (Value, error) {
 	case "YEAR":
 		return DateValue(addYear(t, int(v))), nil
 	case "QUARTER":
-		return DateValue(addMonth(t, 3)), nil
+		return DateValue(addMonth(t, int(v*3))), nil
 	}
 	return nil, fmt.Errorf("unexpected part value %s", part)
 }
@@ -82,6 +84,8 @@ func DATE_SUB(t time.Time, v int64, part string) (Value, error) {
 		return DateValue(addMonth(t, int(-v))), nil
 	case "YEAR":
 		return DateValue(addYear(t, int(-v))), nil
+	case "QUARTER":
+		return DateValue(addMonth(t, int(-v*3))), nil
 	}
 	return nil, fmt.Errorf("unexpected part value %s", part)
 }
@@ -233,9 +237,19 @@ func DATE_TRUNC(t time.Time, part string) (Value, error) {
 	return nil, fmt.Errorf("unexpected part value %s", part)
 }
 
+// supported DATE range is 0001-01-01 to 9999-12-31.
+const (
+	minUnixDate int64 = -719162
+	maxUnixDate int64 = 2932896
+
+	secondsPerDay = int64(24 * time.Hour / time.Second)
+)
+
 func DATE_FROM_UNIX_DATE(unixdate int64) (Value, error) {
-	t := time.Unix(int64(time.Duration(unixdate)*24*time.Hour/time.Second), 0)
-	return DateValue(t), nil
+	if unixdate < minUnixDate || maxUnixDate < unixdate {
+		return nil, fmt.Errorf("DATE_FROM_UNIX_DATE: date value %d is out of range", unixdate)
+	}
+	return DateValue(time.Unix(unixdate*secondsPerDay, 0).UTC()), nil
 }
 
 func FORMAT_DATE(format string, t time.Time) (Value, error) {
@@ -285,7 +299,13 @@ func PARSE_DATE(format, date string) (Value, error) {
 }
 
 func UNIX_DATE(t time.Time) (Value, error) {
-	return IntValue(t.Unix() / int64(24*time.Hour/time.Second)), nil
+	sec := t.Unix()
+	days := sec / secondsPerDay
+	if sec%secondsPerDay < 0 {
+		// round toward negative infinity for dates before the epoch.
+		days--
+	}
+	return IntValue(days), nil
 }
 
 func addMonth(t time.Time, m int) time.Time {
@@ -311,3 +331,56 @@ func addYear(t time.Time, y int) time.Time {
 	}
 	return t.AddDate(y, 0, 0)
 }
+
+// defaultBucketOrigin is the origin used by DATE_BUCKET, DATETIME_BUCKET and TIMESTAMP_BUCKET
+// when it isn't specified.
+var defaultBucketOrigin = time.Date(1950, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func DATE_BUCKET(t time.Time, width *IntervalValue, origin time.Time) (Value, error) {
+	if width.Hours != 0 || width.Minutes != 0 || width.Seconds != 0 || width.SubSecondNanos != 0 {
+		return nil, fmt.Errorf("DATE_BUCKET: bucket width must not have time parts")
+	}
+	bucket, err := timeBucket(t, width, origin)
+	if err != nil {
+		return nil, fmt.Errorf("DATE_BUCKET: %w", err)
+	}
+	return DateValue(bucket), nil
+}
+
+// timeBucket returns the start of the bucket containing t.
+// Buckets are width wide and aligned to origin, so the result is origin + floor((t - origin) / width) * width.
+func timeBucket(t time.Time, width *IntervalValue, origin time.Time) (time.Time, error) {
+	months := int64(width.Years)*12 + int64(width.Months)
+	hasDayTimeParts := width.Days != 0 || width.Hours != 0 || width.Minutes != 0 || width.Seconds != 0 || width.SubSecondNanos != 0
+	if months != 0 && hasDayTimeParts {
+		return time.Time{}, fmt.Errorf("bucket width must not mix Y-M parts and D H:M:S parts")
+	}
+	if months != 0 {
+		if months < 0 {
+			return time.Time{}, fmt.Errorf("bucket width must be positive")
+		}
+		addMonths := func(m int64) time.Time {
+			return addInterval(origin, &IntervalValue{IntervalValue: &bigquery.IntervalValue{Months: int32(m)}}, 1)
+		}
+		diff := int64(t.Year()-origin.Year())*12 + int64(t.Month()) - int64(origin.Month())
+		if addMonths(diff).After(t) {
+			diff--
+		}
+		return addMonths(floorDiv(diff, months) * months), nil
+	}
+	widthMicros := ((int64(width.Days)*24+int64(width.Hours))*60+int64(width.Minutes))*60*1000000 +
+		int64(width.Seconds)*1000000 + int64(width.SubSecondNanos)/1000
+	if widthMicros <= 0 {
+		return time.Time{}, fmt.Errorf("bucket width must be positive")
+	}
+	diff := t.UnixMicro() - origin.UnixMicro()
+	return time.UnixMicro(origin.UnixMicro() + floorDiv(diff, widthMicros)*widthMicros).In(origin.Location()), nil
+}
+
+func floorDiv(a, b int64) int64 {
+	q := a / b
+	if (a%b != 0) && ((a < 0) != (b < 0)) {
+		q--
+	}
+	return q
+}
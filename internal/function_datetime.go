@@ -199,6 +199,14 @@ func DATETIME_DIFF(a, b time.Time, part string) (Value, error) {
 	return value, nil
 }
 
+func DATETIME_BUCKET(t time.Time, width *IntervalValue, origin time.Time) (Value, error) {
+	bucket, err := timeBucket(t, width, origin)
+	if err != nil {
+		return nil, fmt.Errorf("DATETIME_BUCKET: %w", err)
+	}
+	return DatetimeValue(bucket), nil
+}
+
 func DATETIME_TRUNC(t time.Time, part string) (Value, error) {
 	switch part {
 	case "MICROSECOND":
@@ -3,8 +3,10 @@ package internal
 import (
 	"bytes"
 	"fmt"
+	"math"
 	"strconv"
 	"strings"
+	"unicode"
 
 	"github.com/goccy/go-json"
 )
@@ -243,7 +245,11 @@ func parseFloat(param *FormatParam, args []Value) ([]rune, error) {
 	if floatFmt == 'F' {
 		floatFmt = 'f'
 	}
-	format := strconv.FormatFloat(v, byte(floatFmt), precision, 64)
+	if (floatFmt == 'g' || floatFmt == 'G') && precision == 0 {
+		// a precision of zero is treated as one for %g and %G in the same way as printf.
+		precision = 1
+	}
+	format := formatFloatWithSpecifier(v, floatFmt, precision)
 	remain := width - len(format)
 	if remain > 0 {
 		if param.flag == FormatFlagZero {
@@ -269,6 +275,26 @@ func parseFloat(param *FormatParam, args []Value) ([]rune, error) {
 	return []rune(format), nil
 }
 
+// formatFloatWithSpecifier formats v like printf does, so infinity and NaN are written as inf and nan
+// ( or INF and NAN for uppercase specifiers ) instead of Go's +Inf and NaN.
+func formatFloatWithSpecifier(v float64, specifier rune, precision int) string {
+	var special string
+	switch {
+	case math.IsNaN(v):
+		special = "nan"
+	case math.IsInf(v, 1):
+		special = "inf"
+	case math.IsInf(v, -1):
+		special = "-inf"
+	default:
+		return strconv.FormatFloat(v, byte(specifier), precision, 64)
+	}
+	if unicode.IsUpper(specifier) {
+		return strings.ToUpper(special)
+	}
+	return special
+}
+
 func parseOneLineJSON(param *FormatParam, args []Value) ([]rune, error) {
 	v, err := args[0].ToString()
 	if err != nil {
@@ -411,18 +437,20 @@ func (p *FormatPrecision) format(args []Value) (int, []Value) {
 	}
 	if p.fromArg {
 		precision, _ := args[0].ToInt64()
-		if precision <= 0 {
-			return -1, args[1:]
+		if precision < 0 {
+			// a negative precision is taken as if the precision were omitted.
+			return 6, args[1:]
 		}
 		return int(precision), args[1:]
 	}
 	if p.num <= 0 {
-		return -1, args
+		return 0, args
 	}
 	return p.num, args
 }
 
-func parseFormat(format string, args ...Value) (string, error) {
+// parseFormat returns nil if an argument is NULL, except for the argument formatted by %t or %T.
+func parseFormat(format string, args ...Value) (Value, error) {
 	ctx := &FormatContext{src: []rune(format)}
 	formatArgs := args
 	result := []rune{}
@@ -435,16 +463,16 @@ func parseFormat(format string, args ...Value) (string, error) {
 		}
 		ctx.progress(1)
 		if len(ctx.src) <= ctx.idx {
-			return "", fmt.Errorf("invalid format")
+			return nil, fmt.Errorf("invalid format")
 		}
 		flag := parseFormatFlag(ctx)
 		width, err := parseFormatWidth(ctx)
 		if err != nil {
-			return "", err
+			return nil, err
 		}
 		precision, err := parseFormatPrecision(ctx)
 		if err != nil {
-			return "", err
+			return nil, err
 		}
 		specifier := ctx.current()
 		param := &FormatParam{
@@ -455,19 +483,29 @@ func parseFormat(format string, args ...Value) (string, error) {
 		}
 		info, exists := formatSpecifierTable[param.specifier]
 		if !exists {
-			return "", fmt.Errorf("unexpected format type %%%c", specifier)
+			return nil, fmt.Errorf("unexpected format type %%%c", specifier)
 		}
 		num := param.requiredArgNum()
 		if len(formatArgs) < num {
-			return "", fmt.Errorf("not enough arguments for format")
+			return nil, fmt.Errorf("not enough arguments for format")
 		}
 		args := formatArgs[:num]
-		if err := param.validateArgs(info, args); err != nil {
-			return "", fmt.Errorf("invalid argument type: %w", err)
-		}
-		text, err := info.parse(param, args)
-		if err != nil {
-			return "", err
+		var text []rune
+		if existsNull(args) {
+			isPrintable := param.specifier == 't' || param.specifier == 'T'
+			if !isPrintable || existsNull(args[:num-1]) {
+				return nil, nil
+			}
+			text = []rune("NULL")
+		} else {
+			if err := param.validateArgs(info, args); err != nil {
+				return nil, fmt.Errorf("invalid argument type: %w", err)
+			}
+			parsed, err := info.parse(param, args)
+			if err != nil {
+				return nil, err
+			}
+			text = parsed
 		}
 		if len(formatArgs) > num {
 			formatArgs = formatArgs[num:]
@@ -477,7 +515,7 @@ func parseFormat(format string, args ...Value) (string, error) {
 		result = append(result, text...)
 		ctx.progress(1)
 	}
-	return string(result), nil
+	return StringValue(string(result)), nil
 }
 
 func parseFormatFlag(ctx *FormatContext) FormatFlag {
@@ -543,6 +581,7 @@ func parseFormatPrecision(ctx *FormatContext) (*FormatPrecision, error) {
 			ctx.progress(1)
 			continue
 		case '*':
+			ctx.progress(1)
 			return &FormatPrecision{fromArg: true}, nil
 		}
 		end = ctx.idx
@@ -556,5 +595,6 @@ func parseFormatPrecision(ctx *FormatContext) (*FormatPrecision, error) {
 		}
 		return &FormatPrecision{num: int(i64)}, nil
 	}
-	return nil, nil
+	// a period without digits specifies a precision of zero.
+	return &FormatPrecision{}, nil
 }
@@ -0,0 +1,267 @@
+package internal
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+type GeographyShape string
+
+const (
+	GeographyPointShape              GeographyShape = "POINT"
+	GeographyLineStringShape         GeographyShape = "LINESTRING"
+	GeographyPolygonShape            GeographyShape = "POLYGON"
+	GeographyGeometryCollectionShape GeographyShape = "GEOMETRYCOLLECTION"
+)
+
+type GeographyPoint struct {
+	Longitude float64
+	Latitude  float64
+}
+
+func (p *GeographyPoint) String() string {
+	return fmt.Sprintf(
+		"%s %s",
+		strconv.FormatFloat(p.Longitude, 'f', -1, 64),
+		strconv.FormatFloat(p.Latitude, 'f', -1, 64),
+	)
+}
+
+func newGeographyPoint(lng, lat float64) (*GeographyPoint, error) {
+	if math.IsNaN(lng) || lng < -180 || lng > 180 {
+		return nil, fmt.Errorf("longitude must be in the range [-180, 180]: %v", lng)
+	}
+	if math.IsNaN(lat) || lat < -90 || lat > 90 {
+		return nil, fmt.Errorf("latitude must be in the range [-90, 90]: %v", lat)
+	}
+	return &GeographyPoint{Longitude: lng, Latitude: lat}, nil
+}
+
+func ST_GEOGPOINT(lng, lat float64) (Value, error) {
+	if math.IsNaN(lng) || math.IsInf(lng, 0) {
+		return nil, fmt.Errorf("ST_GEOGPOINT: longitude must be finite: %v", lng)
+	}
+	// longitude outside of [-180, 180] is wrapped in the same way as BigQuery.
+	point, err := newGeographyPoint(math.Remainder(lng, 360), lat)
+	if err != nil {
+		return nil, fmt.Errorf("ST_GEOGPOINT: %w", err)
+	}
+	return &GeographyValue{
+		Shape:       GeographyPointShape,
+		Coordinates: [][]*GeographyPoint{{point}},
+	}, nil
+}
+
+func ST_GEOGFROMTEXT(wkt string) (Value, error) {
+	gv, err := parseGeography(wkt)
+	if err != nil {
+		return nil, fmt.Errorf("ST_GEOGFROMTEXT: %w", err)
+	}
+	return gv, nil
+}
+
+func ST_ASTEXT(v Value) (Value, error) {
+	s, err := v.ToString()
+	if err != nil {
+		return nil, err
+	}
+	return StringValue(s), nil
+}
+
+// earthRadiusMeters is the mean radius of the earth used by BigQuery.
+const earthRadiusMeters = 6371008.8
+
+func ST_DISTANCE(a, b *GeographyValue) (Value, error) {
+	if len(a.Coordinates) == 0 || len(b.Coordinates) == 0 {
+		return nil, nil
+	}
+	if a.Shape != GeographyPointShape || b.Shape != GeographyPointShape {
+		return nil, fmt.Errorf("ST_DISTANCE: only the distance between points is supported currently")
+	}
+	return FloatValue(haversineDistance(a.Coordinates[0][0], b.Coordinates[0][0])), nil
+}
+
+func ST_DWITHIN(a, b *GeographyValue, distance float64) (Value, error) {
+	if len(a.Coordinates) == 0 || len(b.Coordinates) == 0 {
+		return BoolValue(false), nil
+	}
+	if a.Shape != GeographyPointShape || b.Shape != GeographyPointShape {
+		return nil, fmt.Errorf("ST_DWITHIN: only the distance between points is supported currently")
+	}
+	return BoolValue(haversineDistance(a.Coordinates[0][0], b.Coordinates[0][0]) <= distance), nil
+}
+
+// haversineDistance returns the great-circle distance in meters between two points on a sphere.
+func haversineDistance(a, b *GeographyPoint) float64 {
+	toRadian := func(degree float64) float64 { return degree * math.Pi / 180 }
+	lat1 := toRadian(a.Latitude)
+	lat2 := toRadian(b.Latitude)
+	dLat := lat2 - lat1
+	dLng := toRadian(b.Longitude - a.Longitude)
+	h := math.Pow(math.Sin(dLat/2), 2) + math.Cos(lat1)*math.Cos(lat2)*math.Pow(math.Sin(dLng/2), 2)
+	return 2 * earthRadiusMeters * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// parseGeography parses WKT of a POINT, LINESTRING or POLYGON.
+func parseGeography(wkt string) (*GeographyValue, error) {
+	p := &wktParser{src: wkt}
+	shape := GeographyShape(strings.ToUpper(p.readWord()))
+	switch shape {
+	case GeographyPointShape, GeographyLineStringShape, GeographyPolygonShape, GeographyGeometryCollectionShape:
+	case "":
+		return nil, fmt.Errorf("failed to parse WKT %q: geometry type is missing", wkt)
+	default:
+		return nil, fmt.Errorf("failed to parse WKT %q: unsupported geometry type %s", wkt, shape)
+	}
+	if strings.EqualFold(p.readWord(), "EMPTY") {
+		if !p.isEnd() {
+			return nil, fmt.Errorf("failed to parse WKT %q: unexpected text at position %d", wkt, p.pos)
+		}
+		return &GeographyValue{Shape: shape}, nil
+	}
+	coords, err := p.parseCoordinates(shape)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse WKT %q: %w", wkt, err)
+	}
+	if !p.isEnd() {
+		return nil, fmt.Errorf("failed to parse WKT %q: unexpected text at position %d", wkt, p.pos)
+	}
+	return &GeographyValue{Shape: shape, Coordinates: coords}, nil
+}
+
+type wktParser struct {
+	src string
+	pos int
+}
+
+func (p *wktParser) parseCoordinates(shape GeographyShape) ([][]*GeographyPoint, error) {
+	switch shape {
+	case GeographyPointShape:
+		points, err := p.parsePoints()
+		if err != nil {
+			return nil, err
+		}
+		if len(points) != 1 {
+			return nil, fmt.Errorf("POINT must have exactly one coordinate")
+		}
+		return [][]*GeographyPoint{points}, nil
+	case GeographyLineStringShape:
+		points, err := p.parsePoints()
+		if err != nil {
+			return nil, err
+		}
+		if len(points) < 2 {
+			return nil, fmt.Errorf("LINESTRING must have at least two points")
+		}
+		return [][]*GeographyPoint{points}, nil
+	case GeographyPolygonShape:
+		if err := p.expect('('); err != nil {
+			return nil, err
+		}
+		var rings [][]*GeographyPoint
+		for {
+			ring, err := p.parsePoints()
+			if err != nil {
+				return nil, err
+			}
+			if len(ring) < 4 {
+				return nil, fmt.Errorf("POLYGON ring must have at least four points")
+			}
+			if *ring[0] != *ring[len(ring)-1] {
+				return nil, fmt.Errorf("POLYGON ring must be closed")
+			}
+			rings = append(rings, ring)
+			if !p.consume(',') {
+				break
+			}
+		}
+		if err := p.expect(')'); err != nil {
+			return nil, err
+		}
+		return rings, nil
+	}
+	return nil, fmt.Errorf("%s must be EMPTY", shape)
+}
+
+func (p *wktParser) parsePoints() ([]*GeographyPoint, error) {
+	if err := p.expect('('); err != nil {
+		return nil, err
+	}
+	var points []*GeographyPoint
+	for {
+		lng, err := p.parseNumber()
+		if err != nil {
+			return nil, err
+		}
+		lat, err := p.parseNumber()
+		if err != nil {
+			return nil, err
+		}
+		point, err := newGeographyPoint(lng, lat)
+		if err != nil {
+			return nil, err
+		}
+		points = append(points, point)
+		if !p.consume(',') {
+			break
+		}
+	}
+	if err := p.expect(')'); err != nil {
+		return nil, err
+	}
+	return points, nil
+}
+
+func (p *wktParser) parseNumber() (float64, error) {
+	p.skipSpaces()
+	start := p.pos
+	for p.pos < len(p.src) && strings.ContainsRune("0123456789+-.eE", rune(p.src[p.pos])) {
+		p.pos++
+	}
+	if start == p.pos {
+		return 0, fmt.Errorf("expected number at position %d", start)
+	}
+	f64, err := strconv.ParseFloat(p.src[start:p.pos], 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid number %s at position %d", p.src[start:p.pos], start)
+	}
+	return f64, nil
+}
+
+func (p *wktParser) readWord() string {
+	p.skipSpaces()
+	start := p.pos
+	for p.pos < len(p.src) && ('a' <= p.src[p.pos] && p.src[p.pos] <= 'z' || 'A' <= p.src[p.pos] && p.src[p.pos] <= 'Z') {
+		p.pos++
+	}
+	return p.src[start:p.pos]
+}
+
+func (p *wktParser) consume(c byte) bool {
+	p.skipSpaces()
+	if p.pos < len(p.src) && p.src[p.pos] == c {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *wktParser) expect(c byte) error {
+	if !p.consume(c) {
+		return fmt.Errorf("expected '%c' at position %d", c, p.pos)
+	}
+	return nil
+}
+
+func (p *wktParser) skipSpaces() {
+	for p.pos < len(p.src) && strings.ContainsRune(" \t\r\n", rune(p.src[p.pos])) {
+		p.pos++
+	}
+}
+
+func (p *wktParser) isEnd() bool {
+	p.skipSpaces()
+	return p.pos == len(p.src)
+}
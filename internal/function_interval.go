@@ -2,6 +2,7 @@ package internal
 
 import (
 	"fmt"
+	"time"
 
 	"cloud.google.com/go/bigquery"
 )
@@ -39,46 +40,26 @@ func MAKE_INTERVAL(year, month, day, hour, minute, second int64) (Value, error)
 	}, nil
 }
 
+// JUSTIFY_DAYS converts every 30 days to a month and every 12 months to a year.
+// The remainder keeps the sign of the original value, as BigQuery does.
 func JUSTIFY_DAYS(v *IntervalValue) (Value, error) {
-	if v.Days > 29 {
-		v.Months += v.Days / 30
-		v.Days %= 30
-	} else if v.Days < -29 {
-		v.Months += v.Days / 30
-		v.Days %= 30
-	}
-	if v.Months > 11 {
-		v.Months -= 12
-		v.Years++
-	} else if v.Months < -11 {
-		v.Months += 12
-		v.Years--
-	}
+	v.Months += v.Days / 30
+	v.Days %= 30
+	v.Years += v.Months / 12
+	v.Months %= 12
 	return v, nil
 }
 
+// JUSTIFY_HOURS converts every 24 hours to a day, carrying sub-second, second and minute overflow first.
 func JUSTIFY_HOURS(v *IntervalValue) (Value, error) {
-	if v.Seconds > 59 {
-		v.Minutes += v.Seconds / 60
-		v.Seconds %= 60
-	} else if v.Seconds < -59 {
-		v.Minutes += v.Seconds / 60
-		v.Seconds %= 60
-	}
-	if v.Minutes > 59 {
-		v.Hours += v.Minutes / 60
-		v.Minutes %= 60
-	} else if v.Minutes < -59 {
-		v.Hours += v.Hours / 60
-		v.Minutes %= 60
-	}
-	if v.Hours > 23 {
-		v.Days += v.Hours / 24
-		v.Hours %= 24
-	} else if v.Hours < -23 {
-		v.Days += v.Hours / 24
-		v.Hours %= 24
-	}
+	v.Seconds += v.SubSecondNanos / int32(time.Second)
+	v.SubSecondNanos %= int32(time.Second)
+	v.Minutes += v.Seconds / 60
+	v.Seconds %= 60
+	v.Hours += v.Minutes / 60
+	v.Minutes %= 60
+	v.Days += v.Hours / 24
+	v.Hours %= 24
 	return v, nil
 }
 
@@ -3,7 +3,10 @@ package internal
 import (
 	"bytes"
 	"fmt"
+	"math/big"
 	"reflect"
+	"strconv"
+	"strings"
 
 	"github.com/goccy/go-json"
 )
@@ -31,6 +34,9 @@ func JSON_SUBSCRIPT(v string, field Value) (Value, error) {
 		if err != nil {
 			return nil, err
 		}
+		if index < 0 {
+			return nil, nil
+		}
 		p, err := json.CreatePath(fmt.Sprintf(`$[%d]`, index))
 		if err != nil {
 			return nil, err
@@ -46,6 +52,8 @@ func JSON_SUBSCRIPT(v string, field Value) (Value, error) {
 			return nil, err
 		}
 		path = p
+	default:
+		return nil, fmt.Errorf("JSON_SUBSCRIPT: unexpected subscript type %T", field)
 	}
 	extracted, err := path.Extract([]byte(v))
 	if err != nil {
@@ -65,7 +73,7 @@ func JSON_EXTRACT(v, path string) (Value, error) {
 	if p.UsedDoubleQuotePathSelector() {
 		return nil, fmt.Errorf("JSON_EXTRACT: doesn't use double quote path selector")
 	}
-	extracted, err := p.Extract([]byte(v))
+	extracted, err := extractJSONPath(p, path, v)
 	if err != nil {
 		return nil, err
 	}
@@ -99,6 +107,9 @@ func JSON_EXTRACT_SCALAR(v, path string) (Value, error) {
 		return nil, nil
 	}
 	value := values[0]
+	if !reflect.ValueOf(value).IsValid() {
+		return nil, nil
+	}
 	switch reflect.ValueOf(value).Type().Kind() {
 	case reflect.Map, reflect.Slice:
 		return nil, nil
@@ -114,7 +125,7 @@ func JSON_EXTRACT_ARRAY(v, path string) (Value, error) {
 	if p.UsedDoubleQuotePathSelector() {
 		return nil, fmt.Errorf("JSON_EXTRACT_ARRAY: doesn't use double quote path selector")
 	}
-	extracted, err := p.Extract([]byte(v))
+	extracted, err := extractJSONPath(p, path, v)
 	if err != nil {
 		return nil, err
 	}
@@ -167,16 +178,16 @@ func JSON_EXTRACT_STRING_ARRAY(v, path string) (Value, error) {
 	for i := 0; i < rv.Len(); i++ {
 		elem := rv.Index(i).Interface()
 		elemV := reflect.ValueOf(elem)
+		if !elemV.IsValid() {
+			// json null element
+			ret.values = append(ret.values, nil)
+			continue
+		}
 		elemKind := elemV.Type().Kind()
 		if elemKind == reflect.Map || elemKind == reflect.Slice {
 			return nil, nil
 		}
-		jsonValue := fmt.Sprint(elem)
-		if jsonValue == "null" {
-			ret.values = append(ret.values, nil)
-		} else {
-			ret.values = append(ret.values, StringValue(jsonValue))
-		}
+		ret.values = append(ret.values, StringValue(fmt.Sprint(elem)))
 	}
 	return ret, nil
 }
@@ -189,7 +200,7 @@ func JSON_QUERY(v, path string) (Value, error) {
 	if p.UsedSingleQuotePathSelector() {
 		return nil, fmt.Errorf("JSON_QUERY: doesn't use single quote path selector")
 	}
-	extracted, err := p.Extract([]byte(v))
+	extracted, err := extractJSONPath(p, path, v)
 	if err != nil {
 		return nil, err
 	}
@@ -241,7 +252,7 @@ func JSON_QUERY_ARRAY(v, path string) (Value, error) {
 	if p.UsedSingleQuotePathSelector() {
 		return nil, fmt.Errorf("JSON_QUERY_ARRAY: doesn't use single quote path selector")
 	}
-	extracted, err := p.Extract([]byte(v))
+	extracted, err := extractJSONPath(p, path, v)
 	if err != nil {
 		return nil, err
 	}
@@ -294,26 +305,218 @@ func JSON_VALUE_ARRAY(v, path string) (Value, error) {
 	for i := 0; i < rv.Len(); i++ {
 		elem := rv.Index(i).Interface()
 		elemV := reflect.ValueOf(elem)
+		if !elemV.IsValid() {
+			// json null element
+			ret.values = append(ret.values, nil)
+			continue
+		}
 		elemKind := elemV.Type().Kind()
 		if elemKind == reflect.Map || elemKind == reflect.Slice {
 			return nil, nil
 		}
-		jsonValue := fmt.Sprint(elem)
-		if jsonValue == "null" {
-			ret.values = append(ret.values, nil)
-		} else {
-			ret.values = append(ret.values, StringValue(jsonValue))
-		}
+		ret.values = append(ret.values, StringValue(fmt.Sprint(elem)))
 	}
 	return ret, nil
 }
 
 func PARSE_JSON(expr, mode string) (Value, error) {
+	switch mode {
+	case "exact", "round":
+	default:
+		return nil, fmt.Errorf("PARSE_JSON: unexpected wide_number_mode: %s", mode)
+	}
+	dec := json.NewDecoder(bytes.NewBufferString(expr))
+	dec.UseNumber()
 	var v interface{}
-	if err := json.Unmarshal([]byte(expr), &v); err != nil {
+	if err := dec.Decode(&v); err != nil {
+		return nil, fmt.Errorf("PARSE_JSON: invalid JSON string %q: %w", expr, err)
+	}
+	if dec.More() && dec.InputOffset() < int64(len(expr)) {
+		return nil, fmt.Errorf("PARSE_JSON: invalid JSON string %q: unexpected data after top-level value", expr)
+	}
+	rounded := map[string]string{}
+	if err := parseJSONWideNumber(v, mode, rounded); err != nil {
+		return nil, err
+	}
+	if len(rounded) == 0 {
+		return JsonValue(expr), nil
+	}
+	return JsonValue(replaceJSONNumbers(expr, rounded)), nil
+}
+
+// parseJSONWideNumber validates all numbers in v according to wide_number_mode.
+// In round mode, numbers that cannot be stored in INT64, UINT64 or FLOAT64 without loss
+// are rounded to FLOAT64 and stored to rounded as the map from the original literal to the rounded one.
+func parseJSONWideNumber(v interface{}, mode string, rounded map[string]string) error {
+	switch vv := v.(type) {
+	case json.Number:
+		if isJSONInteger(vv) {
+			return nil
+		}
+		f, exact, err := jsonNumberToFloat64(vv)
+		if err != nil {
+			return fmt.Errorf("PARSE_JSON: %w", err)
+		}
+		if exact {
+			return nil
+		}
+		if mode == "exact" {
+			return fmt.Errorf("PARSE_JSON: cannot convert %s without loss of precision in exact mode", vv)
+		}
+		rounded[vv.String()] = strconv.FormatFloat(f, 'g', -1, 64)
+	case []interface{}:
+		for _, elem := range vv {
+			if err := parseJSONWideNumber(elem, mode, rounded); err != nil {
+				return err
+			}
+		}
+	case map[string]interface{}:
+		for _, elem := range vv {
+			if err := parseJSONWideNumber(elem, mode, rounded); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// replaceJSONNumbers replaces the number literals in the valid JSON document v by replacer.
+// The other parts of the document such as the order of the object keys are kept as is.
+func replaceJSONNumbers(v string, replacer map[string]string) string {
+	var b strings.Builder
+	for i := 0; i < len(v); {
+		switch c := v[i]; {
+		case c == '"':
+			end := i + 1
+			for end < len(v) && v[end] != '"' {
+				if v[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			b.WriteString(v[i : end+1])
+			i = end + 1
+		case c == '-' || ('0' <= c && c <= '9'):
+			end := i + 1
+			for end < len(v) && strings.IndexByte("0123456789+-.eE", v[end]) >= 0 {
+				end++
+			}
+			if replaced, exists := replacer[v[i:end]]; exists {
+				b.WriteString(replaced)
+			} else {
+				b.WriteString(v[i:end])
+			}
+			i = end
+		default:
+			b.WriteByte(c)
+			i++
+		}
+	}
+	return b.String()
+}
+
+func isJSONInteger(n json.Number) bool {
+	if _, err := strconv.ParseInt(n.String(), 10, 64); err == nil {
+		return true
+	}
+	if _, err := strconv.ParseUint(n.String(), 10, 64); err == nil {
+		return true
+	}
+	return false
+}
+
+// jsonNumberToFloat64 converts n to FLOAT64 and reports whether the conversion is lossless.
+func jsonNumberToFloat64(n json.Number) (float64, bool, error) {
+	s := n.String()
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("number %s is out of range", s)
+	}
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return 0, false, fmt.Errorf("invalid number %s", s)
+	}
+	// the number is lossless if it round-trips through the shortest FLOAT64 representation.
+	rounded, _ := new(big.Rat).SetString(strconv.FormatFloat(f, 'g', -1, 64))
+	return f, r.Cmp(rounded) == 0, nil
+}
+
+func decodeJSONWithNumber(v JsonValue) (interface{}, error) {
+	dec := json.NewDecoder(bytes.NewBufferString(string(v)))
+	dec.UseNumber()
+	var ret interface{}
+	if err := dec.Decode(&ret); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func JSON_BOOL(v JsonValue) (Value, error) {
+	decoded, err := decodeJSONWithNumber(v)
+	if err != nil {
+		return nil, err
+	}
+	b, ok := decoded.(bool)
+	if !ok {
+		return nil, fmt.Errorf("BOOL: the provided JSON input is not a boolean: %s", v)
+	}
+	return BoolValue(b), nil
+}
+
+func JSON_INT64(v JsonValue) (Value, error) {
+	decoded, err := decodeJSONWithNumber(v)
+	if err != nil {
 		return nil, err
 	}
-	return JsonValue(expr), nil
+	n, ok := decoded.(json.Number)
+	if !ok {
+		return nil, fmt.Errorf("INT64: the provided JSON input is not an integer: %s", v)
+	}
+	if i64, err := strconv.ParseInt(n.String(), 10, 64); err == nil {
+		return IntValue(i64), nil
+	}
+	// accept the number that has an integral value such as 10.0 or 1e2.
+	r, ok := new(big.Rat).SetString(n.String())
+	if !ok || !r.IsInt() || !r.Num().IsInt64() {
+		return nil, fmt.Errorf("INT64: the provided JSON number cannot be converted to an integer without loss: %s", v)
+	}
+	return IntValue(r.Num().Int64()), nil
+}
+
+func JSON_FLOAT64(v JsonValue, mode string) (Value, error) {
+	switch mode {
+	case "exact", "round":
+	default:
+		return nil, fmt.Errorf("FLOAT64: unexpected wide_number_mode: %s", mode)
+	}
+	decoded, err := decodeJSONWithNumber(v)
+	if err != nil {
+		return nil, err
+	}
+	n, ok := decoded.(json.Number)
+	if !ok {
+		return nil, fmt.Errorf("FLOAT64: the provided JSON input is not a number: %s", v)
+	}
+	f, exact, err := jsonNumberToFloat64(n)
+	if err != nil {
+		return nil, fmt.Errorf("FLOAT64: %w", err)
+	}
+	if !exact && mode == "exact" {
+		return nil, fmt.Errorf("FLOAT64: cannot convert %s without loss of precision in exact mode", n)
+	}
+	return FloatValue(f), nil
+}
+
+func JSON_STRING(v JsonValue) (Value, error) {
+	decoded, err := decodeJSONWithNumber(v)
+	if err != nil {
+		return nil, err
+	}
+	s, ok := decoded.(string)
+	if !ok {
+		return nil, fmt.Errorf("STRING: the provided JSON input is not a string: %s", v)
+	}
+	return StringValue(s), nil
 }
 
 func TO_JSON(v Value, stringifyWideNumbers bool) (Value, error) {
@@ -335,3 +538,214 @@ func TO_JSON_STRING(v Value, prettyPrint bool) (Value, error) {
 func JSON_TYPE(v JsonValue) (Value, error) {
 	return StringValue(v.Type()), nil
 }
+
+// jsonPathSelector is a selector of JSONPath evaluated by evalJSONPath.
+type jsonPathSelector struct {
+	key       string
+	index     int
+	isIndex   bool
+	wildcard  bool
+	recursive bool
+}
+
+// extractJSONPath extracts the values specified by path from v.
+// go-json doesn't evaluate recursive descent ( `..` ) and wildcard ( `[*]` ) correctly,
+// so the path containing them is evaluated by evalJSONPath and all matched values are returned as one JSON array.
+// The other paths are evaluated by go-json.
+func extractJSONPath(p *json.Path, path, v string) ([][]byte, error) {
+	if !strings.Contains(path, "..") && !strings.Contains(path, "[*]") {
+		return p.Extract([]byte(v))
+	}
+	selectors, err := parseJSONPath(path)
+	if err != nil {
+		return nil, err
+	}
+	matched, err := evalJSONPath(json.RawMessage(v), selectors)
+	if err != nil {
+		return nil, err
+	}
+	if matched == nil {
+		matched = []json.RawMessage{}
+	}
+	b, err := json.Marshal(matched)
+	if err != nil {
+		return nil, err
+	}
+	return [][]byte{b}, nil
+}
+
+func parseJSONPath(path string) ([]*jsonPathSelector, error) {
+	if !strings.HasPrefix(path, "$") {
+		return nil, fmt.Errorf("JSONPath must start with '$': %q", path)
+	}
+	var selectors []*jsonPathSelector
+	for i := 1; i < len(path); {
+		sel := &jsonPathSelector{}
+		switch path[i] {
+		case '.':
+			i++
+			if i < len(path) && path[i] == '.' {
+				sel.recursive = true
+				i++
+			}
+			if i >= len(path) {
+				return nil, fmt.Errorf("invalid JSONPath %q: selector is not found after '.'", path)
+			}
+			switch path[i] {
+			case '"':
+				end := strings.IndexByte(path[i+1:], '"')
+				if end < 0 {
+					return nil, fmt.Errorf("invalid JSONPath %q: unclosed double quote", path)
+				}
+				sel.key = path[i+1 : i+1+end]
+				i += end + 2
+			default:
+				start := i
+				for i < len(path) && path[i] != '.' && path[i] != '[' {
+					i++
+				}
+				sel.key = path[start:i]
+			}
+		case '[':
+			end, err := parseJSONPathBracket(path, i, sel)
+			if err != nil {
+				return nil, err
+			}
+			i = end
+		default:
+			return nil, fmt.Errorf("invalid JSONPath %q: unexpected character %q", path, path[i])
+		}
+		selectors = append(selectors, sel)
+	}
+	return selectors, nil
+}
+
+// parseJSONPathBracket parses the subscript selector starts from path[start] ( '[' ) and returns the position after ']'.
+func parseJSONPathBracket(path string, start int, sel *jsonPathSelector) (int, error) {
+	end := strings.IndexByte(path[start:], ']')
+	if end < 0 {
+		return 0, fmt.Errorf("invalid JSONPath %q: unclosed bracket", path)
+	}
+	content := path[start+1 : start+end]
+	if strings.HasPrefix(content, "'") {
+		// quoted key may contain ']'.
+		closeQuote := strings.IndexByte(path[start+2:], '\'')
+		if closeQuote < 0 || start+2+closeQuote+1 >= len(path) || path[start+2+closeQuote+1] != ']' {
+			return 0, fmt.Errorf("invalid JSONPath %q: unclosed single quote", path)
+		}
+		sel.key = path[start+2 : start+2+closeQuote]
+		return start + 2 + closeQuote + 2, nil
+	}
+	switch {
+	case content == "*":
+		sel.wildcard = true
+	default:
+		index, err := strconv.Atoi(content)
+		if err != nil || index < 0 {
+			return 0, fmt.Errorf("invalid JSONPath %q: invalid subscript %q", path, content)
+		}
+		sel.index = index
+		sel.isIndex = true
+	}
+	return start + end + 1, nil
+}
+
+func evalJSONPath(v json.RawMessage, selectors []*jsonPathSelector) ([]json.RawMessage, error) {
+	values := []json.RawMessage{v}
+	for _, sel := range selectors {
+		targets := values
+		if sel.recursive {
+			var descendants []json.RawMessage
+			for _, value := range values {
+				found, err := jsonDescendants(value)
+				if err != nil {
+					return nil, err
+				}
+				descendants = append(descendants, found...)
+			}
+			targets = descendants
+		}
+		var next []json.RawMessage
+		for _, target := range targets {
+			keys, children, err := jsonChildren(target)
+			if err != nil {
+				return nil, err
+			}
+			for idx, child := range children {
+				switch {
+				case sel.wildcard:
+				case sel.isIndex:
+					if keys != nil || idx != sel.index {
+						continue
+					}
+				default:
+					if keys == nil || keys[idx] != sel.key {
+						continue
+					}
+				}
+				next = append(next, child)
+			}
+		}
+		values = next
+	}
+	return values, nil
+}
+
+// jsonDescendants returns v and all values contained in v in document order.
+func jsonDescendants(v json.RawMessage) ([]json.RawMessage, error) {
+	ret := []json.RawMessage{v}
+	_, children, err := jsonChildren(v)
+	if err != nil {
+		return nil, err
+	}
+	for _, child := range children {
+		found, err := jsonDescendants(child)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, found...)
+	}
+	return ret, nil
+}
+
+// jsonChildren returns the member names and values of the object, or the elements of the array.
+// keys is nil if v is not an object.
+func jsonChildren(v json.RawMessage) ([]string, []json.RawMessage, error) {
+	trimmed := bytes.TrimLeft(v, " \t\r\n")
+	if len(trimmed) == 0 {
+		return nil, nil, nil
+	}
+	switch trimmed[0] {
+	case '[':
+		var elems []json.RawMessage
+		if err := json.Unmarshal(trimmed, &elems); err != nil {
+			return nil, nil, err
+		}
+		return nil, elems, nil
+	case '{':
+		dec := json.NewDecoder(bytes.NewReader(trimmed))
+		if _, err := dec.Token(); err != nil {
+			return nil, nil, err
+		}
+		keys := []string{}
+		var values []json.RawMessage
+		for dec.More() {
+			tok, err := dec.Token()
+			if err != nil {
+				return nil, nil, err
+			}
+			key, ok := tok.(string)
+			if !ok {
+				return nil, nil, fmt.Errorf("unexpected object key %v", tok)
+			}
+			var value json.RawMessage
+			if err := dec.Decode(&value); err != nil {
+				return nil, nil, err
+			}
+			keys = append(keys, key)
+			values = append(values, value)
+		}
+		return keys, values, nil
+	}
+	return nil, nil, nil
+}
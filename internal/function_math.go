@@ -3,13 +3,28 @@ package internal
 import (
 	"fmt"
 	"math"
+	"math/big"
 	"math/rand"
+	"strconv"
 	"time"
 
 	"gonum.org/v1/gonum/floats/scalar"
 )
 
 func ABS(a Value) (Value, error) {
+	// integers and numerics are handled without float64 to keep their precision.
+	switch v := a.(type) {
+	case IntValue:
+		if v == math.MinInt64 {
+			return nil, fmt.Errorf("ABS: int64 overflow: %d", v)
+		}
+		if v < 0 {
+			return -v, nil
+		}
+		return v, nil
+	case *NumericValue:
+		return &NumericValue{Rat: new(big.Rat).Abs(v.Rat), isBigNumeric: v.isBigNumeric}, nil
+	}
 	f64, err := a.ToFloat64()
 	if err != nil {
 		return nil, err
@@ -88,6 +103,29 @@ func RAND() (Value, error) {
 	return FloatValue(rand.Float64()), nil
 }
 
+// SAMPLE_RAND returns the random number in [0, 1) used to decide whether the row is sampled by TABLESAMPLE.
+// If seed is specified, the number is derived from seed and rowID by splitmix64 so that the same rows are sampled every time.
+//
+//nolint:gosec
+func SAMPLE_RAND(seed, rowID Value) (Value, error) {
+	if seed == nil {
+		return FloatValue(rand.Float64()), nil
+	}
+	s, err := seed.ToInt64()
+	if err != nil {
+		return nil, err
+	}
+	id, err := rowID.ToInt64()
+	if err != nil {
+		return nil, err
+	}
+	x := uint64(s) + uint64(id)*0x9e3779b97f4a7c15
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	x ^= x >> 31
+	return FloatValue(float64(x>>11) / (1 << 53)), nil
+}
+
 func SQRT(x Value) (Value, error) {
 	f, err := x.ToFloat64()
 	if err != nil {
@@ -96,6 +134,14 @@ func SQRT(x Value) (Value, error) {
 	return FloatValue(math.Sqrt(f)), nil
 }
 
+func CBRT(x Value) (Value, error) {
+	f, err := x.ToFloat64()
+	if err != nil {
+		return nil, err
+	}
+	return FloatValue(math.Cbrt(f)), nil
+}
+
 func POW(x, y Value) (Value, error) {
 	xf, err := x.ToFloat64()
 	if err != nil {
@@ -261,6 +307,32 @@ func SAFE_SUBTRACT(x, y Value) (Value, error) {
 }
 
 func MOD(x, y Value) (Value, error) {
+	// the result has the same sign as x in the same way as BigQuery.
+	switch xv := x.(type) {
+	case IntValue:
+		yv, err := y.ToInt64()
+		if err != nil {
+			return nil, err
+		}
+		if yv == 0 {
+			return nil, fmt.Errorf("MOD: zero divided")
+		}
+		return IntValue(int64(xv) % yv), nil
+	case *NumericValue:
+		yv, err := y.ToRat()
+		if err != nil {
+			return nil, err
+		}
+		if yv.Sign() == 0 {
+			return nil, fmt.Errorf("MOD: zero divided")
+		}
+		quo := new(big.Int).Quo(
+			new(big.Int).Mul(xv.Rat.Num(), yv.Denom()),
+			new(big.Int).Mul(xv.Rat.Denom(), yv.Num()),
+		)
+		mod := new(big.Rat).Sub(xv.Rat, new(big.Rat).Mul(yv, new(big.Rat).SetInt(quo)))
+		return &NumericValue{Rat: mod, isBigNumeric: xv.isBigNumeric}, nil
+	}
 	xv, err := x.ToFloat64()
 	if err != nil {
 		return nil, err
@@ -276,6 +348,12 @@ func MOD(x, y Value) (Value, error) {
 }
 
 func ROUND(x Value, precision int) (Value, error) {
+	if nv, ok := x.(*NumericValue); ok {
+		return &NumericValue{
+			Rat:          roundRat(nv.Rat, precision, roundHalfAwayFromZero),
+			isBigNumeric: nv.isBigNumeric,
+		}, nil
+	}
 	xv, err := x.ToFloat64()
 	if err != nil {
 		return nil, err
@@ -283,14 +361,131 @@ func ROUND(x Value, precision int) (Value, error) {
 	return FloatValue(scalar.Round(xv, precision)), nil
 }
 
-func TRUNC(x Value) (Value, error) {
+// roundRat rounds r to precision decimal places ( to the left of the decimal point if precision is negative )
+// by using rounder which rounds num / denom to an integer. denom is always positive.
+// The precision is bounded before computing 10^|precision|, so that a huge precision doesn't allocate the huge scale.
+func roundRat(r *big.Rat, precision int, rounder func(num, denom *big.Int) *big.Int) *big.Rat {
+	if precision >= 0 {
+		if s, ok := decimalScale(r.Denom()); ok {
+			if precision >= s {
+				return new(big.Rat).Set(r)
+			}
+		} else if limit := len(r.Denom().String()) + bigNumericScale; precision > limit {
+			// r has no finite decimal representation, so the digits after the limit don't affect the result
+			// which is kept within the scale of BIGNUMERIC.
+			precision = limit
+		}
+	} else if -precision > len(new(big.Int).Abs(roundTowardZero(r.Num(), r.Denom())).String()) {
+		// |r| is less than half of 10^-precision, so it's rounded or truncated to zero.
+		return new(big.Rat)
+	}
+	scale := new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(absInt(precision))), nil))
+	scaled := new(big.Rat).Set(r)
+	if precision >= 0 {
+		scaled.Mul(scaled, scale)
+	} else {
+		scaled.Quo(scaled, scale)
+	}
+	ret := new(big.Rat).SetInt(rounder(scaled.Num(), scaled.Denom()))
+	if precision >= 0 {
+		return ret.Quo(ret, scale)
+	}
+	return ret.Mul(ret, scale)
+}
+
+// decimalScale returns the number of fractional digits of the rational number which has denom as the denominator.
+// It returns false if the number has no finite decimal representation.
+func decimalScale(denom *big.Int) (int, bool) {
+	twos := int(denom.TrailingZeroBits())
+	d := new(big.Int).Rsh(denom, uint(twos))
+	five := big.NewInt(5)
+	fives := 0
+	for {
+		q, m := new(big.Int).QuoRem(d, five, new(big.Int))
+		if m.Sign() != 0 {
+			break
+		}
+		d = q
+		fives++
+	}
+	if d.Cmp(big.NewInt(1)) != 0 {
+		return 0, false
+	}
+	if twos > fives {
+		return twos, true
+	}
+	return fives, true
+}
+
+func roundHalfAwayFromZero(num, denom *big.Int) *big.Int {
+	// floor(|num / denom| + 1/2) = floor((2 * |num| + denom) / (2 * denom))
+	v := new(big.Int).Abs(num)
+	v.Add(v.Lsh(v, 1), denom)
+	v.Quo(v, new(big.Int).Lsh(denom, 1))
+	if num.Sign() < 0 {
+		v.Neg(v)
+	}
+	return v
+}
+
+func roundTowardZero(num, denom *big.Int) *big.Int {
+	return new(big.Int).Quo(num, denom)
+}
+
+func roundTowardNegativeInf(num, denom *big.Int) *big.Int {
+	// Div implements Euclidean division, so it rounds toward negative infinity for the positive denom.
+	return new(big.Int).Div(num, denom)
+}
+
+func roundTowardPositiveInf(num, denom *big.Int) *big.Int {
+	v := roundTowardNegativeInf(new(big.Int).Neg(num), denom)
+	return v.Neg(v)
+}
+
+// roundFloat64 rounds the shortest decimal representation of f, so that the result isn't affected by
+// the binary representation error ( e.g. 0.29 * 100 = 28.999999999999996 ).
+func roundFloat64(f float64, precision int, rounder func(num, denom *big.Int) *big.Int) float64 {
+	if math.IsInf(f, 0) || math.IsNaN(f) {
+		return f
+	}
+	r, ok := new(big.Rat).SetString(strconv.FormatFloat(f, 'g', -1, 64))
+	if !ok {
+		return f
+	}
+	ret, _ := roundRat(r, precision, rounder).Float64()
+	return ret
+}
+
+func absInt(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+func TRUNC(x Value, precision int) (Value, error) {
+	if nv, ok := x.(*NumericValue); ok {
+		return &NumericValue{
+			Rat:          roundRat(nv.Rat, precision, roundTowardZero),
+			isBigNumeric: nv.isBigNumeric,
+		}, nil
+	}
 	xv, err := x.ToFloat64()
 	if err != nil {
 		return nil, err
 	}
-	return FloatValue(math.Trunc(xv)), nil
+	if precision == 0 {
+		return FloatValue(math.Trunc(xv)), nil
+	}
+	return FloatValue(roundFloat64(xv, precision, roundTowardZero)), nil
 }
 func CEIL(x Value) (Value, error) {
+	if nv, ok := x.(*NumericValue); ok {
+		return &NumericValue{
+			Rat:          roundRat(nv.Rat, 0, roundTowardPositiveInf),
+			isBigNumeric: nv.isBigNumeric,
+		}, nil
+	}
 	xv, err := x.ToFloat64()
 	if err != nil {
 		return nil, err
@@ -299,6 +494,12 @@ func CEIL(x Value) (Value, error) {
 }
 
 func FLOOR(x Value) (Value, error) {
+	if nv, ok := x.(*NumericValue); ok {
+		return &NumericValue{
+			Rat:          roundRat(nv.Rat, 0, roundTowardNegativeInf),
+			isBigNumeric: nv.isBigNumeric,
+		}, nil
+	}
 	xv, err := x.ToFloat64()
 	if err != nil {
 		return nil, err
@@ -414,6 +615,66 @@ func ATAN2(x, y Value) (Value, error) {
 	return FloatValue(math.Atan2(xv, yv)), nil
 }
 
+func COT(x Value) (Value, error) {
+	xv, err := x.ToFloat64()
+	if err != nil {
+		return nil, err
+	}
+	if xv == 0 {
+		return nil, fmt.Errorf("COT: zero divided")
+	}
+	return FloatValue(1 / math.Tan(xv)), nil
+}
+
+func COTH(x Value) (Value, error) {
+	xv, err := x.ToFloat64()
+	if err != nil {
+		return nil, err
+	}
+	if xv == 0 {
+		return nil, fmt.Errorf("COTH: zero divided")
+	}
+	return FloatValue(1 / math.Tanh(xv)), nil
+}
+
+func CSC(x Value) (Value, error) {
+	xv, err := x.ToFloat64()
+	if err != nil {
+		return nil, err
+	}
+	if xv == 0 {
+		return nil, fmt.Errorf("CSC: zero divided")
+	}
+	return FloatValue(1 / math.Sin(xv)), nil
+}
+
+func CSCH(x Value) (Value, error) {
+	xv, err := x.ToFloat64()
+	if err != nil {
+		return nil, err
+	}
+	if xv == 0 {
+		return nil, fmt.Errorf("CSCH: zero divided")
+	}
+	return FloatValue(1 / math.Sinh(xv)), nil
+}
+
+func SEC(x Value) (Value, error) {
+	xv, err := x.ToFloat64()
+	if err != nil {
+		return nil, err
+	}
+	return FloatValue(1 / math.Cos(xv)), nil
+}
+
+func SECH(x Value) (Value, error) {
+	xv, err := x.ToFloat64()
+	if err != nil {
+		return nil, err
+	}
+	return FloatValue(1 / math.Cosh(xv)), nil
+}
+
 func RANGE_BUCKET(point Value, array *ArrayValue) (Value, error) {
 	if point == nil {
 		return nil, nil
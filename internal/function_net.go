@@ -3,6 +3,7 @@ package internal
 import (
 	"encoding/binary"
 	"fmt"
+	"math"
 	"net"
 	"net/netip"
 	"net/url"
@@ -68,6 +69,10 @@ func NET_IP_TRUNC(v []byte, length int64) (Value, error) {
 }
 
 func NET_IPV4_FROM_INT64(v int64) (Value, error) {
+	// the value must fit in 32 bits either as an unsigned integer or sign-extended from a 32-bit integer.
+	if v < math.MinInt32 || v > math.MaxUint32 {
+		return nil, fmt.Errorf("NET.IPV4_FROM_INT64: %d is out of range for ipv4 address", v)
+	}
 	ip := make([]byte, 4)
 	binary.BigEndian.PutUint32(ip, uint32(v))
 	return BytesValue(ip), nil
@@ -88,7 +93,8 @@ func NET_PUBLIC_SUFFIX(v string) (Value, error) {
 	host := parsed.Hostname()
 	suffix, err := publicSuffix(host)
 	if err != nil {
-		return nil, fmt.Errorf("NET.PUBLIC_SUFFIX: invalid hostname %s", host)
+		// unparseable hostname is treated as NULL in the same way as unparseable url.
+		return nil, nil
 	}
 	if suffix == "" {
 		return nil, nil
@@ -105,7 +111,8 @@ func NET_REG_DOMAIN(v string) (Value, error) {
 	splitHost := strings.Split(host, ".")
 	suffix, err := publicSuffix(host)
 	if err != nil {
-		return nil, fmt.Errorf("NET.REG_DOMAIN: invalid hostname %s", host)
+		// unparseable hostname is treated as NULL in the same way as unparseable url.
+		return nil, nil
 	}
 	splitSuffix := strings.Split(suffix, ".")
 	if host == "" || suffix == "" || len(splitHost) <= len(splitSuffix) {
@@ -162,5 +169,8 @@ func parseIP(v string) ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
+	if ip.Zone() != "" {
+		return nil, fmt.Errorf("unexpected zone in ip address %s", v)
+	}
 	return ip.AsSlice(), nil
 }
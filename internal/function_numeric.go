@@ -2,21 +2,92 @@ package internal
 
 import (
 	"fmt"
+	"math"
 	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	numericScale    = 9
+	bigNumericScale = 38
+
+	// exponents beyond this limit always overflow ( or round to zero ) so they are handled without big.Rat.
+	maxNumericLiteralExponent = 1000
+)
+
+var (
+	parseNumericPattern = regexp.MustCompile(`^([0-9]+\.?[0-9]*|\.[0-9]+)(?:[eE]([+-]?[0-9]+))?$`)
+
+	maxNumericValue, _    = new(big.Rat).SetString("99999999999999999999999999999.999999999")
+	minNumericValue, _    = new(big.Rat).SetString("-99999999999999999999999999999.999999999")
+	maxBigNumericValue, _ = new(big.Rat).SetString("578960446186580977117854925043439539266.34992332820282019728792003956564819967")
+	minBigNumericValue, _ = new(big.Rat).SetString("-578960446186580977117854925043439539266.34992332820282019728792003956564819968")
 )
 
 func PARSE_NUMERIC(numeric string) (Value, error) {
-	r := new(big.Rat)
-	if _, ok := r.SetString(numeric); !ok {
-		return nil, fmt.Errorf("unexpected numeric literal: %s", numeric)
+	r, err := parseNumericLiteral(numeric, numericScale, minNumericValue, maxNumericValue)
+	if err != nil {
+		return nil, fmt.Errorf("PARSE_NUMERIC: %w", err)
 	}
 	return &NumericValue{Rat: r}, nil
 }
 
 func PARSE_BIGNUMERIC(numeric string) (Value, error) {
-	r := new(big.Rat)
-	if _, ok := r.SetString(numeric); !ok {
-		return nil, fmt.Errorf("unexpected numeric literal: %s", numeric)
+	r, err := parseNumericLiteral(numeric, bigNumericScale, minBigNumericValue, maxBigNumericValue)
+	if err != nil {
+		return nil, fmt.Errorf("PARSE_BIGNUMERIC: %w", err)
 	}
 	return &NumericValue{Rat: r, isBigNumeric: true}, nil
 }
+
+// parseNumericLiteral parses a decimal string surrounded by optional whitespace with an optional leading sign,
+// rounds it half away from zero to scale fractional digits and validates that it lies between minValue and maxValue.
+func parseNumericLiteral(numeric string, scale int, minValue, maxValue *big.Rat) (*big.Rat, error) {
+	text := strings.TrimSpace(numeric)
+	var negative bool
+	if strings.HasPrefix(text, "+") || strings.HasPrefix(text, "-") {
+		negative = text[0] == '-'
+		text = strings.TrimSpace(text[1:])
+	}
+	matched := parseNumericPattern.FindStringSubmatch(text)
+	if matched == nil {
+		return nil, fmt.Errorf("unexpected numeric literal: %s", numeric)
+	}
+	mantissa, exponent := matched[1], matched[2]
+	if exponent != "" {
+		exp, err := strconv.Atoi(exponent)
+		if err != nil || exp > maxNumericLiteralExponent || exp < -maxNumericLiteralExponent {
+			if strings.Trim(mantissa, "0.") == "" || strings.HasPrefix(exponent, "-") {
+				return new(big.Rat), nil
+			}
+			return nil, fmt.Errorf("numeric value %s is out of range", numeric)
+		}
+	}
+	r, ok := new(big.Rat).SetString(text)
+	if !ok {
+		return nil, fmt.Errorf("unexpected numeric literal: %s", numeric)
+	}
+	if negative {
+		r.Neg(r)
+	}
+	r = roundRat(r, scale, roundHalfAwayFromZero)
+	if r.Cmp(minValue) < 0 || r.Cmp(maxValue) > 0 {
+		return nil, fmt.Errorf("numeric value %s is out of range", numeric)
+	}
+	return r, nil
+}
+
+// roundFloatToNumeric rounds the exact binary value of f half away from zero to scale fractional digits
+// and validates that it lies between minValue and maxValue, as FLOAT64 value is converted to NUMERIC or BIGNUMERIC.
+func roundFloatToNumeric(f float64, scale int, minValue, maxValue *big.Rat) (*big.Rat, error) {
+	if math.IsInf(f, 0) || math.IsNaN(f) {
+		return nil, fmt.Errorf("illegal conversion of non-finite floating point number to numeric: %v", f)
+	}
+	r := roundRat(new(big.Rat).SetFloat64(f), scale, roundHalfAwayFromZero)
+	if r.Cmp(minValue) < 0 || r.Cmp(maxValue) > 0 {
+		return nil, fmt.Errorf("numeric value %v is out of range", f)
+	}
+	return r, nil
+}
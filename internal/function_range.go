@@ -0,0 +1,291 @@
+package internal
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// rangeElementTypeName returns the name of the element type of RANGE for the boundary value.
+// It returns an empty string if the value can't be used as a boundary of RANGE.
+func rangeElementTypeName(v Value) string {
+	switch v.(type) {
+	case DateValue:
+		return "DATE"
+	case DatetimeValue:
+		return "DATETIME"
+	case TimestampValue:
+		return "TIMESTAMP"
+	}
+	return ""
+}
+
+func RANGE(start, end Value) (Value, error) {
+	for _, boundary := range []Value{start, end} {
+		if boundary == nil {
+			continue
+		}
+		if rangeElementTypeName(boundary) == "" {
+			return nil, fmt.Errorf("RANGE: unsupported element type %T", boundary)
+		}
+	}
+	if start == nil || end == nil {
+		return &RangeValue{Start: start, End: end}, nil
+	}
+	if reflect.TypeOf(start) != reflect.TypeOf(end) {
+		return nil, fmt.Errorf(
+			"RANGE: start and end must be the same type but got %s and %s",
+			rangeElementTypeName(start), rangeElementTypeName(end),
+		)
+	}
+	isLT, err := start.LT(end)
+	if err != nil {
+		return nil, err
+	}
+	if !isLT {
+		return nil, fmt.Errorf("RANGE: start %s must be less than end %s", start.Format('t'), end.Format('t'))
+	}
+	return &RangeValue{Start: start, End: end}, nil
+}
+
+func toRangeValue(name string, v Value) (*RangeValue, error) {
+	rv, ok := v.(*RangeValue)
+	if !ok {
+		return nil, fmt.Errorf("%s: argument must be RANGE type but got %T", name, v)
+	}
+	return rv, nil
+}
+
+func RANGE_START(v Value) (Value, error) {
+	rv, err := toRangeValue("RANGE_START", v)
+	if err != nil {
+		return nil, err
+	}
+	return rv.Start, nil
+}
+
+func RANGE_END(v Value) (Value, error) {
+	rv, err := toRangeValue("RANGE_END", v)
+	if err != nil {
+		return nil, err
+	}
+	return rv.End, nil
+}
+
+// elementType returns the type of the boundary values, or nil if both boundaries are unbounded.
+func (rv *RangeValue) elementType() reflect.Type {
+	if rv.Start != nil {
+		return reflect.TypeOf(rv.Start)
+	}
+	if rv.End != nil {
+		return reflect.TypeOf(rv.End)
+	}
+	return nil
+}
+
+func checkRangeElementType(name string, a, b reflect.Type) error {
+	if a == nil || b == nil || a == b {
+		return nil
+	}
+	return fmt.Errorf("%s: mismatch element types of RANGE %s and %s", name, a, b)
+}
+
+// rangeStartLTE reports whether the start boundary a is before or equal to the start boundary b.
+// An unbounded start is before any start.
+func rangeStartLTE(a, b Value) (bool, error) {
+	if a == nil {
+		return true, nil
+	}
+	if b == nil {
+		return false, nil
+	}
+	return a.LTE(b)
+}
+
+// rangeEndLTE reports whether the end boundary a is before or equal to the end boundary b.
+// An unbounded end is after any end.
+func rangeEndLTE(a, b Value) (bool, error) {
+	if b == nil {
+		return true, nil
+	}
+	if a == nil {
+		return false, nil
+	}
+	return a.LTE(b)
+}
+
+// rangeStartBeforeEnd reports whether the start boundary is before the end boundary of another range.
+func rangeStartBeforeEnd(start, end Value) (bool, error) {
+	if start == nil || end == nil {
+		return true, nil
+	}
+	return start.LT(end)
+}
+
+func RANGE_CONTAINS(outer, v Value) (Value, error) {
+	rv, err := toRangeValue("RANGE_CONTAINS", outer)
+	if err != nil {
+		return nil, err
+	}
+	if inner, ok := v.(*RangeValue); ok {
+		if err := checkRangeElementType("RANGE_CONTAINS", rv.elementType(), inner.elementType()); err != nil {
+			return nil, err
+		}
+		startContained, err := rangeStartLTE(rv.Start, inner.Start)
+		if err != nil {
+			return nil, err
+		}
+		if !startContained {
+			return BoolValue(false), nil
+		}
+		endContained, err := rangeEndLTE(inner.End, rv.End)
+		if err != nil {
+			return nil, err
+		}
+		return BoolValue(endContained), nil
+	}
+	if rangeElementTypeName(v) == "" {
+		return nil, fmt.Errorf("RANGE_CONTAINS: unsupported argument type %T", v)
+	}
+	if err := checkRangeElementType("RANGE_CONTAINS", rv.elementType(), reflect.TypeOf(v)); err != nil {
+		return nil, err
+	}
+	afterStart, err := rangeStartLTE(rv.Start, v)
+	if err != nil {
+		return nil, err
+	}
+	if !afterStart {
+		return BoolValue(false), nil
+	}
+	beforeEnd, err := rangeStartBeforeEnd(v, rv.End)
+	if err != nil {
+		return nil, err
+	}
+	return BoolValue(beforeEnd), nil
+}
+
+func RANGE_OVERLAPS(a, b Value) (Value, error) {
+	r1, err := toRangeValue("RANGE_OVERLAPS", a)
+	if err != nil {
+		return nil, err
+	}
+	r2, err := toRangeValue("RANGE_OVERLAPS", b)
+	if err != nil {
+		return nil, err
+	}
+	overlaps, err := rangeOverlaps("RANGE_OVERLAPS", r1, r2)
+	if err != nil {
+		return nil, err
+	}
+	return BoolValue(overlaps), nil
+}
+
+// rangeOverlaps reports whether the half-open ranges share at least one value.
+// Adjacent ranges such as [a, b) and [b, c) don't overlap.
+func rangeOverlaps(name string, r1, r2 *RangeValue) (bool, error) {
+	if err := checkRangeElementType(name, r1.elementType(), r2.elementType()); err != nil {
+		return false, err
+	}
+	cond, err := rangeStartBeforeEnd(r1.Start, r2.End)
+	if err != nil {
+		return false, err
+	}
+	if !cond {
+		return false, nil
+	}
+	return rangeStartBeforeEnd(r2.Start, r1.End)
+}
+
+func RANGE_INTERSECT(a, b Value) (Value, error) {
+	r1, err := toRangeValue("RANGE_INTERSECT", a)
+	if err != nil {
+		return nil, err
+	}
+	r2, err := toRangeValue("RANGE_INTERSECT", b)
+	if err != nil {
+		return nil, err
+	}
+	overlaps, err := rangeOverlaps("RANGE_INTERSECT", r1, r2)
+	if err != nil {
+		return nil, err
+	}
+	if !overlaps {
+		return nil, fmt.Errorf("RANGE_INTERSECT: provided ranges %s and %s don't overlap", r1, r2)
+	}
+	start := r2.Start
+	isFirstLater, err := rangeStartLTE(r2.Start, r1.Start)
+	if err != nil {
+		return nil, err
+	}
+	if isFirstLater {
+		start = r1.Start
+	}
+	end := r2.End
+	isFirstEarlier, err := rangeEndLTE(r1.End, r2.End)
+	if err != nil {
+		return nil, err
+	}
+	if isFirstEarlier {
+		end = r1.End
+	}
+	return &RangeValue{Start: start, End: end}, nil
+}
+
+func addRangeBoundaryInterval(v Value, iv *IntervalValue, n int) (Value, error) {
+	switch vv := v.(type) {
+	case DateValue:
+		if iv.Hours != 0 || iv.Minutes != 0 || iv.Seconds != 0 || iv.SubSecondNanos != 0 {
+			return nil, fmt.Errorf("interval for RANGE<DATE> must not have time parts")
+		}
+		return DateValue(addInterval(time.Time(vv), iv, n)), nil
+	case DatetimeValue:
+		return DatetimeValue(addInterval(time.Time(vv), iv, n)), nil
+	case TimestampValue:
+		return TimestampValue(addInterval(time.Time(vv), iv, n)), nil
+	}
+	return nil, fmt.Errorf("unsupported element type of RANGE %T", v)
+}
+
+// GENERATE_RANGE_ARRAY splits the range into sub-ranges of step.
+// The n-th sub-range starts at start + n * step so that month intervals don't drift at the end of month.
+func GENERATE_RANGE_ARRAY(v Value, step *IntervalValue, includeLastPartialRange bool) (Value, error) {
+	rv, err := toRangeValue("GENERATE_RANGE_ARRAY", v)
+	if err != nil {
+		return nil, err
+	}
+	if rv.Start == nil || rv.End == nil {
+		return nil, fmt.Errorf("GENERATE_RANGE_ARRAY: range must be bounded but got %s", rv)
+	}
+	arr := &ArrayValue{}
+	start := rv.Start
+	for n := 1; ; n++ {
+		end, err := addRangeBoundaryInterval(rv.Start, step, n)
+		if err != nil {
+			return nil, fmt.Errorf("GENERATE_RANGE_ARRAY: %w", err)
+		}
+		isForward, err := start.LT(end)
+		if err != nil {
+			return nil, err
+		}
+		if !isForward {
+			return nil, fmt.Errorf("GENERATE_RANGE_ARRAY: step must be positive interval but got %s", step.Format('t'))
+		}
+		isLast, err := rv.End.LTE(end)
+		if err != nil {
+			return nil, err
+		}
+		if !isLast {
+			arr.values = append(arr.values, &RangeValue{Start: start, End: end})
+			start = end
+			continue
+		}
+		isPartial, err := rv.End.LT(end)
+		if err != nil {
+			return nil, err
+		}
+		if !isPartial || includeLastPartialRange {
+			arr.values = append(arr.values, &RangeValue{Start: start, End: rv.End})
+		}
+		return arr, nil
+	}
+}
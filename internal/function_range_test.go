@@ -0,0 +1,444 @@
+package internal
+
+import (
+	"testing"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+)
+
+func rangeTestDate(t *testing.T, s string) Value {
+	t.Helper()
+	if s == "" {
+		return nil
+	}
+	date, err := parseDate(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return DateValue(date)
+}
+
+func rangeTestValue(t *testing.T, start, end string) *RangeValue {
+	t.Helper()
+	v, err := RANGE(rangeTestDate(t, start), rangeTestDate(t, end))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return v.(*RangeValue)
+}
+
+func TestRange(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		start    string
+		end      string
+		expected string
+	}{
+		{name: "bounded", start: "2024-01-01", end: "2024-02-01", expected: "[2024-01-01, 2024-02-01)"},
+		{name: "unbounded start", end: "2024-02-01", expected: "[UNBOUNDED, 2024-02-01)"},
+		{name: "unbounded end", start: "2024-01-01", expected: "[2024-01-01, UNBOUNDED)"},
+		{name: "unbounded", expected: "[UNBOUNDED, UNBOUNDED)"},
+	} {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			rv := rangeTestValue(t, test.start, test.end)
+			got, err := rv.ToString()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != test.expected {
+				t.Fatalf("expected %s but got %s", test.expected, got)
+			}
+		})
+	}
+	t.Run("timestamp", func(t *testing.T) {
+		v, err := RANGE(
+			TimestampValue(time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)),
+			TimestampValue(time.Date(2024, 1, 1, 12, 30, 0, 0, time.UTC)),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := v.ToString()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expected := "[2024-01-01 10:00:00+00, 2024-01-01 12:30:00+00)"; got != expected {
+			t.Fatalf("expected %s but got %s", expected, got)
+		}
+	})
+	t.Run("round trip", func(t *testing.T) {
+		for _, rv := range []*RangeValue{
+			rangeTestValue(t, "2024-01-01", "2024-02-01"),
+			rangeTestValue(t, "", "2024-02-01"),
+			rangeTestValue(t, "", ""),
+		} {
+			encoded, err := EncodeValue(rv)
+			if err != nil {
+				t.Fatal(err)
+			}
+			decoded, err := DecodeValue(encoded)
+			if err != nil {
+				t.Fatal(err)
+			}
+			eq, err := rv.EQ(decoded)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !eq {
+				t.Fatalf("failed to round trip %s: got %v", rv, decoded)
+			}
+		}
+	})
+	for _, test := range []struct {
+		name  string
+		start Value
+		end   Value
+	}{
+		{name: "start equals end", start: rangeTestDate(t, "2024-01-01"), end: rangeTestDate(t, "2024-01-01")},
+		{name: "start after end", start: rangeTestDate(t, "2024-02-01"), end: rangeTestDate(t, "2024-01-01")},
+		{name: "mismatch types", start: rangeTestDate(t, "2024-01-01"), end: TimestampValue(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))},
+		{name: "unsupported type", start: IntValue(1), end: IntValue(2)},
+	} {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := RANGE(test.start, test.end); err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
+
+func TestRangeStartEnd(t *testing.T) {
+	for _, test := range []struct {
+		name          string
+		value         Value
+		expectedStart Value
+		expectedEnd   Value
+	}{
+		{
+			name:          "bounded",
+			value:         rangeTestValue(t, "2024-01-01", "2024-02-01"),
+			expectedStart: rangeTestDate(t, "2024-01-01"),
+			expectedEnd:   rangeTestDate(t, "2024-02-01"),
+		},
+		{
+			name:        "unbounded start",
+			value:       rangeTestValue(t, "", "2024-02-01"),
+			expectedEnd: rangeTestDate(t, "2024-02-01"),
+		},
+		{
+			name:          "unbounded end",
+			value:         rangeTestValue(t, "2024-01-01", ""),
+			expectedStart: rangeTestDate(t, "2024-01-01"),
+		},
+		{
+			name: "null",
+		},
+	} {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			start, err := bindRangeStart(test.value)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !rangeTestBoundaryEQ(t, test.expectedStart, start) {
+				t.Fatalf("expected start %v but got %v", test.expectedStart, start)
+			}
+			end, err := bindRangeEnd(test.value)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !rangeTestBoundaryEQ(t, test.expectedEnd, end) {
+				t.Fatalf("expected end %v but got %v", test.expectedEnd, end)
+			}
+		})
+	}
+	t.Run("not range", func(t *testing.T) {
+		if _, err := bindRangeStart(rangeTestDate(t, "2024-01-01")); err == nil {
+			t.Fatal("expected error")
+		}
+		if _, err := bindRangeEnd(StringValue("[2024-01-01, 2024-02-01)")); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
+
+func rangeTestBoundaryEQ(t *testing.T, a, b Value) bool {
+	t.Helper()
+	eq, err := rangeBoundaryEQ(a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return eq
+}
+
+func TestRangeContains(t *testing.T) {
+	outer := rangeTestValue(t, "2024-01-01", "2024-02-01")
+	for _, test := range []struct {
+		name     string
+		outer    Value
+		value    Value
+		expected Value
+	}{
+		{name: "point at start", outer: outer, value: rangeTestDate(t, "2024-01-01"), expected: BoolValue(true)},
+		{name: "point inside", outer: outer, value: rangeTestDate(t, "2024-01-15"), expected: BoolValue(true)},
+		{name: "point at end", outer: outer, value: rangeTestDate(t, "2024-02-01"), expected: BoolValue(false)},
+		{name: "point before start", outer: outer, value: rangeTestDate(t, "2023-12-31"), expected: BoolValue(false)},
+		{name: "point in unbounded start", outer: rangeTestValue(t, "", "2024-02-01"), value: rangeTestDate(t, "1900-01-01"), expected: BoolValue(true)},
+		{name: "point in unbounded end", outer: rangeTestValue(t, "2024-01-01", ""), value: rangeTestDate(t, "9999-12-31"), expected: BoolValue(true)},
+		{name: "same range", outer: outer, value: rangeTestValue(t, "2024-01-01", "2024-02-01"), expected: BoolValue(true)},
+		{name: "inner range", outer: outer, value: rangeTestValue(t, "2024-01-10", "2024-01-20"), expected: BoolValue(true)},
+		{name: "range beyond end", outer: outer, value: rangeTestValue(t, "2024-01-10", "2024-02-02"), expected: BoolValue(false)},
+		{name: "unbounded inner range", outer: outer, value: rangeTestValue(t, "", "2024-01-20"), expected: BoolValue(false)},
+		{name: "unbounded outer range", outer: rangeTestValue(t, "", ""), value: rangeTestValue(t, "", "2024-01-20"), expected: BoolValue(true)},
+		{name: "null", outer: outer},
+	} {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			got, err := bindRangeContains(test.outer, test.value)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != test.expected {
+				t.Fatalf("expected %v but got %v", test.expected, got)
+			}
+		})
+	}
+	t.Run("mismatch element type", func(t *testing.T) {
+		if _, err := bindRangeContains(outer, TimestampValue(time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC))); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
+
+func TestRangeOverlaps(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		a        Value
+		b        Value
+		expected Value
+	}{
+		{name: "overlap", a: rangeTestValue(t, "2024-01-01", "2024-02-01"), b: rangeTestValue(t, "2024-01-15", "2024-03-01"), expected: BoolValue(true)},
+		{name: "inner", a: rangeTestValue(t, "2024-01-01", "2024-02-01"), b: rangeTestValue(t, "2024-01-10", "2024-01-20"), expected: BoolValue(true)},
+		{name: "adjacent", a: rangeTestValue(t, "2024-01-01", "2024-02-01"), b: rangeTestValue(t, "2024-02-01", "2024-03-01"), expected: BoolValue(false)},
+		{name: "disjoint", a: rangeTestValue(t, "2024-01-01", "2024-02-01"), b: rangeTestValue(t, "2024-03-01", "2024-04-01"), expected: BoolValue(false)},
+		{name: "unbounded start", a: rangeTestValue(t, "", "2024-02-01"), b: rangeTestValue(t, "2024-01-31", ""), expected: BoolValue(true)},
+		{name: "unbounded adjacent", a: rangeTestValue(t, "", "2024-02-01"), b: rangeTestValue(t, "2024-02-01", ""), expected: BoolValue(false)},
+		{name: "null", a: rangeTestValue(t, "2024-01-01", "2024-02-01")},
+	} {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			got, err := bindRangeOverlaps(test.a, test.b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != test.expected {
+				t.Fatalf("expected %v but got %v", test.expected, got)
+			}
+			reversed, err := bindRangeOverlaps(test.b, test.a)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if reversed != test.expected {
+				t.Fatalf("expected %v for reversed arguments but got %v", test.expected, reversed)
+			}
+		})
+	}
+	t.Run("not range", func(t *testing.T) {
+		if _, err := bindRangeOverlaps(rangeTestValue(t, "2024-01-01", "2024-02-01"), rangeTestDate(t, "2024-01-15")); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
+
+func TestRangeIntersect(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		a        *RangeValue
+		b        *RangeValue
+		expected *RangeValue
+	}{
+		{
+			name:     "overlap",
+			a:        rangeTestValue(t, "2024-01-01", "2024-02-01"),
+			b:        rangeTestValue(t, "2024-01-15", "2024-03-01"),
+			expected: rangeTestValue(t, "2024-01-15", "2024-02-01"),
+		},
+		{
+			name:     "inner",
+			a:        rangeTestValue(t, "2024-01-01", "2024-02-01"),
+			b:        rangeTestValue(t, "2024-01-10", "2024-01-20"),
+			expected: rangeTestValue(t, "2024-01-10", "2024-01-20"),
+		},
+		{
+			name:     "unbounded start",
+			a:        rangeTestValue(t, "", "2024-02-01"),
+			b:        rangeTestValue(t, "2024-01-15", "2024-03-01"),
+			expected: rangeTestValue(t, "2024-01-15", "2024-02-01"),
+		},
+		{
+			name:     "unbounded end",
+			a:        rangeTestValue(t, "2024-01-01", ""),
+			b:        rangeTestValue(t, "", "2024-03-01"),
+			expected: rangeTestValue(t, "2024-01-01", "2024-03-01"),
+		},
+		{
+			name:     "unbounded",
+			a:        rangeTestValue(t, "", ""),
+			b:        rangeTestValue(t, "2024-01-01", ""),
+			expected: rangeTestValue(t, "2024-01-01", ""),
+		},
+	} {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			for _, args := range [][]Value{{test.a, test.b}, {test.b, test.a}} {
+				got, err := bindRangeIntersect(args...)
+				if err != nil {
+					t.Fatal(err)
+				}
+				eq, err := test.expected.EQ(got)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !eq {
+					t.Fatalf("expected %s but got %v", test.expected, got)
+				}
+			}
+		})
+	}
+	for _, test := range []struct {
+		name string
+		a    *RangeValue
+		b    *RangeValue
+	}{
+		{
+			name: "adjacent",
+			a:    rangeTestValue(t, "2024-01-01", "2024-02-01"),
+			b:    rangeTestValue(t, "2024-02-01", "2024-03-01"),
+		},
+		{
+			name: "adjacent unbounded",
+			a:    rangeTestValue(t, "", "2024-02-01"),
+			b:    rangeTestValue(t, "2024-02-01", ""),
+		},
+		{
+			name: "disjoint",
+			a:    rangeTestValue(t, "2024-01-01", "2024-02-01"),
+			b:    rangeTestValue(t, "2024-03-01", "2024-04-01"),
+		},
+	} {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := bindRangeIntersect(test.a, test.b); err == nil {
+				t.Fatal("expected error")
+			}
+			if _, err := bindRangeIntersect(test.b, test.a); err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+	t.Run("null", func(t *testing.T) {
+		got, err := bindRangeIntersect(rangeTestValue(t, "2024-01-01", "2024-02-01"), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != nil {
+			t.Fatalf("expected null but got %v", got)
+		}
+	})
+}
+
+func TestGenerateRangeArray(t *testing.T) {
+	tenDays := &IntervalValue{IntervalValue: &bigquery.IntervalValue{Days: 10}}
+	for _, test := range []struct {
+		name     string
+		args     []Value
+		expected []*RangeValue
+	}{
+		{
+			name: "exact",
+			args: []Value{rangeTestValue(t, "2024-01-01", "2024-01-21"), tenDays},
+			expected: []*RangeValue{
+				rangeTestValue(t, "2024-01-01", "2024-01-11"),
+				rangeTestValue(t, "2024-01-11", "2024-01-21"),
+			},
+		},
+		{
+			name: "include last partial range",
+			args: []Value{rangeTestValue(t, "2024-01-01", "2024-01-25"), tenDays},
+			expected: []*RangeValue{
+				rangeTestValue(t, "2024-01-01", "2024-01-11"),
+				rangeTestValue(t, "2024-01-11", "2024-01-21"),
+				rangeTestValue(t, "2024-01-21", "2024-01-25"),
+			},
+		},
+		{
+			name: "exclude last partial range",
+			args: []Value{rangeTestValue(t, "2024-01-01", "2024-01-25"), tenDays, BoolValue(false)},
+			expected: []*RangeValue{
+				rangeTestValue(t, "2024-01-01", "2024-01-11"),
+				rangeTestValue(t, "2024-01-11", "2024-01-21"),
+			},
+		},
+		{
+			name:     "only partial range",
+			args:     []Value{rangeTestValue(t, "2024-01-01", "2024-01-05"), tenDays, BoolValue(false)},
+			expected: []*RangeValue{},
+		},
+		{
+			name: "month step",
+			args: []Value{
+				rangeTestValue(t, "2024-01-31", "2024-04-30"),
+				&IntervalValue{IntervalValue: &bigquery.IntervalValue{Months: 1}},
+			},
+			expected: []*RangeValue{
+				rangeTestValue(t, "2024-01-31", "2024-02-29"),
+				rangeTestValue(t, "2024-02-29", "2024-03-31"),
+				rangeTestValue(t, "2024-03-31", "2024-04-30"),
+			},
+		},
+	} {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			got, err := bindGenerateRangeArray(test.args...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			arr, err := got.ToArray()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(arr.values) != len(test.expected) {
+				t.Fatalf("expected %d ranges but got %v", len(test.expected), arr)
+			}
+			for idx, expected := range test.expected {
+				eq, err := expected.EQ(arr.values[idx])
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !eq {
+					t.Fatalf("expected %s at %d but got %v", expected, idx, arr.values[idx])
+				}
+			}
+		})
+	}
+	for _, test := range []struct {
+		name string
+		args []Value
+	}{
+		{name: "unbounded", args: []Value{rangeTestValue(t, "2024-01-01", ""), tenDays}},
+		{name: "zero step", args: []Value{rangeTestValue(t, "2024-01-01", "2024-01-21"), &IntervalValue{IntervalValue: &bigquery.IntervalValue{}}}},
+		{name: "negative step", args: []Value{rangeTestValue(t, "2024-01-01", "2024-01-21"), &IntervalValue{IntervalValue: &bigquery.IntervalValue{Days: -1}}}},
+		{name: "time part for date", args: []Value{rangeTestValue(t, "2024-01-01", "2024-01-21"), &IntervalValue{IntervalValue: &bigquery.IntervalValue{Hours: 1}}}},
+		{name: "not range", args: []Value{rangeTestDate(t, "2024-01-01"), tenDays}},
+	} {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := bindGenerateRangeArray(test.args...); err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"sync"
 
-	"github.com/goccy/go-json"
 	"github.com/mattn/go-sqlite3"
 )
 
@@ -54,6 +53,7 @@ var normalFuncs = []*FuncInfo{
 	{Name: "date_sub", BindFunc: bindDateSub},
 	{Name: "date_diff", BindFunc: bindDateDiff},
 	{Name: "date_trunc", BindFunc: bindDateTrunc},
+	{Name: "date_bucket", BindFunc: bindDateBucket},
 	{Name: "date_from_unix_date", BindFunc: bindDateFromUnixDate},
 	{Name: "format_date", BindFunc: bindFormatDate},
 	{Name: "last_day", BindFunc: bindLastDay},
@@ -67,6 +67,7 @@ var normalFuncs = []*FuncInfo{
 	{Name: "datetime_sub", BindFunc: bindDatetimeSub},
 	{Name: "datetime_diff", BindFunc: bindDatetimeDiff},
 	{Name: "datetime_trunc", BindFunc: bindDatetimeTrunc},
+	{Name: "datetime_bucket", BindFunc: bindDatetimeBucket},
 	{Name: "format_datetime", BindFunc: bindFormatDatetime},
 	{Name: "parse_datetime", BindFunc: bindParseDatetime},
 
@@ -88,6 +89,7 @@ var normalFuncs = []*FuncInfo{
 	{Name: "timestamp_sub", BindFunc: bindTimestampSub},
 	{Name: "timestamp_diff", BindFunc: bindTimestampDiff},
 	{Name: "timestamp_trunc", BindFunc: bindTimestampTrunc},
+	{Name: "timestamp_bucket", BindFunc: bindTimestampBucket},
 	{Name: "format_timestamp", BindFunc: bindFormatTimestamp},
 	{Name: "parse_timestamp", BindFunc: bindParseTimestamp},
 	{Name: "timestamp_seconds", BindFunc: bindTimestampSeconds},
@@ -205,7 +207,9 @@ var normalFuncs = []*FuncInfo{
 	{Name: "is_nan", BindFunc: bindIsNaN},
 	{Name: "ieee_divide", BindFunc: bindIEEEDivide},
 	{Name: "rand", BindFunc: bindRand},
+	{Name: "sample_rand", BindFunc: bindSampleRand},
 	{Name: "sqrt", BindFunc: bindSqrt},
+	{Name: "cbrt", BindFunc: bindCbrt},
 	{Name: "pow", BindFunc: bindPow},
 	{Name: "power", BindFunc: bindPow},
 	{Name: "exp", BindFunc: bindExp},
@@ -239,6 +243,12 @@ var normalFuncs = []*FuncInfo{
 	{Name: "atan", BindFunc: bindAtan},
 	{Name: "atanh", BindFunc: bindAtanh},
 	{Name: "atan2", BindFunc: bindAtan2},
+	{Name: "cot", BindFunc: bindCot},
+	{Name: "coth", BindFunc: bindCoth},
+	{Name: "csc", BindFunc: bindCsc},
+	{Name: "csch", BindFunc: bindCsch},
+	{Name: "sec", BindFunc: bindSec},
+	{Name: "sech", BindFunc: bindSech},
 	{Name: "range_bucket", BindFunc: bindRangeBucket},
 
 	// array functions
@@ -248,7 +258,18 @@ var normalFuncs = []*FuncInfo{
 	{Name: "generate_array", BindFunc: bindGenerateArray},
 	{Name: "generate_date_array", BindFunc: bindGenerateDateArray},
 	{Name: "generate_timestamp_array", BindFunc: bindGenerateTimestampArray},
+	{Name: "generate_range_array", BindFunc: bindGenerateRangeArray},
 	{Name: "array_reverse", BindFunc: bindArrayReverse},
+	{Name: "array_first", BindFunc: bindArrayFirst},
+	{Name: "array_last", BindFunc: bindArrayLast},
+	{Name: "array_is_distinct", BindFunc: bindArrayIsDistinct},
+	{Name: "array_includes", BindFunc: bindArrayIncludes},
+	{Name: "array_includes_any", BindFunc: bindArrayIncludesAny},
+	{Name: "array_includes_all", BindFunc: bindArrayIncludesAll},
+	{Name: "array_min", BindFunc: bindArrayMin},
+	{Name: "array_max", BindFunc: bindArrayMax},
+	{Name: "array_sum", BindFunc: bindArraySum},
+	{Name: "array_avg", BindFunc: bindArrayAvg},
 	{Name: "make_array", BindFunc: bindMakeArray},
 	{Name: "make_struct", BindFunc: bindMakeStruct},
 
@@ -286,6 +307,21 @@ var normalFuncs = []*FuncInfo{
 	{Name: "net_public_suffix", BindFunc: bindNetPublicSuffix},
 	{Name: "net_reg_domain", BindFunc: bindNetRegDomain},
 	{Name: "net_safe_ip_from_string", BindFunc: bindNetSafeIpFromString},
+
+	// geography funcs
+	{Name: "st_astext", BindFunc: bindStAstext},
+	{Name: "st_distance", BindFunc: bindStDistance},
+	{Name: "st_dwithin", BindFunc: bindStDwithin},
+	{Name: "st_geogfromtext", BindFunc: bindStGeogfromtext},
+	{Name: "st_geogpoint", BindFunc: bindStGeogpoint},
+
+	// range funcs
+	{Name: "range", BindFunc: bindRange},
+	{Name: "range_start", BindFunc: bindRangeStart},
+	{Name: "range_end", BindFunc: bindRangeEnd},
+	{Name: "range_contains", BindFunc: bindRangeContains},
+	{Name: "range_overlaps", BindFunc: bindRangeOverlaps},
+	{Name: "range_intersect", BindFunc: bindRangeIntersect},
 }
 
 var aggregateFuncs = []*AggregateFuncInfo{
@@ -413,32 +449,7 @@ func RegisterFunctions(conn *sqlite3.SQLiteConn) error {
 		return onceErr
 	}
 
-	if err := conn.RegisterFunc("zetasqlite_decode_array", func(v interface{}) (string, error) {
-		decoded, err := DecodeValue(v)
-		if err != nil {
-			return "", err
-		}
-		if decoded == nil {
-			return "[]", nil
-		}
-		array, err := decoded.ToArray()
-		if err != nil {
-			return "", err
-		}
-		encodedValues := make([]interface{}, 0, len(array.values))
-		for _, value := range array.values {
-			v, err := EncodeValue(value)
-			if err != nil {
-				return "", err
-			}
-			encodedValues = append(encodedValues, v)
-		}
-		b, err := json.Marshal(encodedValues)
-		if err != nil {
-			return "", err
-		}
-		return string(b), err
-	}, true); err != nil {
+	if err := conn.RegisterFunc("zetasqlite_decode_array", decodeArray, true); err != nil {
 		return fmt.Errorf("failed to register decode_array function: %w", err)
 	}
 
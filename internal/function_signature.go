@@ -0,0 +1,203 @@
+package internal
+
+import (
+	"github.com/goccy/go-zetasql/types"
+)
+
+// extraBuiltinFunction represents a BigQuery function that the bundled ZetaSQL analyzer doesn't support yet.
+// It is registered to the catalog with its signatures so that the analyzer can resolve it,
+// and it is executed by the zetasqlite_<name> function registered in normalFuncs.
+type extraBuiltinFunction struct {
+	name       string
+	signatures func() []*types.FunctionSignature
+}
+
+var extraBuiltinFunctions = []*extraBuiltinFunction{
+	{
+		name: "array_first",
+		signatures: func() []*types.FunctionSignature {
+			return []*types.FunctionSignature{
+				newSignature(templatedArgType(types.ArgTypeAny1), templatedArgType(types.ArgArrayTypeAny1)),
+			}
+		},
+	},
+	{
+		name: "array_last",
+		signatures: func() []*types.FunctionSignature {
+			return []*types.FunctionSignature{
+				newSignature(templatedArgType(types.ArgTypeAny1), templatedArgType(types.ArgArrayTypeAny1)),
+			}
+		},
+	},
+	{
+		name: "array_min",
+		signatures: func() []*types.FunctionSignature {
+			return []*types.FunctionSignature{
+				newSignature(templatedArgType(types.ArgTypeAny1), templatedArgType(types.ArgArrayTypeAny1)),
+			}
+		},
+	},
+	{
+		name: "array_max",
+		signatures: func() []*types.FunctionSignature {
+			return []*types.FunctionSignature{
+				newSignature(templatedArgType(types.ArgTypeAny1), templatedArgType(types.ArgArrayTypeAny1)),
+			}
+		},
+	},
+	{
+		name: "array_sum",
+		signatures: func() []*types.FunctionSignature {
+			return []*types.FunctionSignature{
+				newSignature(fixedArgType(types.Int64Type()), fixedArgType(mustArrayType(types.Int64Type()))),
+				newSignature(fixedArgType(types.NumericType()), fixedArgType(mustArrayType(types.NumericType()))),
+				newSignature(fixedArgType(types.BigNumericType()), fixedArgType(mustArrayType(types.BigNumericType()))),
+				newSignature(fixedArgType(types.DoubleType()), fixedArgType(mustArrayType(types.DoubleType()))),
+			}
+		},
+	},
+	{
+		name: "array_avg",
+		signatures: func() []*types.FunctionSignature {
+			return []*types.FunctionSignature{
+				newSignature(fixedArgType(types.DoubleType()), fixedArgType(mustArrayType(types.Int64Type()))),
+				newSignature(fixedArgType(types.NumericType()), fixedArgType(mustArrayType(types.NumericType()))),
+				newSignature(fixedArgType(types.BigNumericType()), fixedArgType(mustArrayType(types.BigNumericType()))),
+				newSignature(fixedArgType(types.DoubleType()), fixedArgType(mustArrayType(types.DoubleType()))),
+			}
+		},
+	},
+	{
+		name: "array_includes_all",
+		signatures: func() []*types.FunctionSignature {
+			return []*types.FunctionSignature{
+				newSignature(fixedArgType(types.BoolType()), templatedArgType(types.ArgArrayTypeAny1), templatedArgType(types.ArgArrayTypeAny1)),
+			}
+		},
+	},
+	{
+		name: "cbrt",
+		signatures: func() []*types.FunctionSignature {
+			return []*types.FunctionSignature{
+				newSignature(fixedArgType(types.DoubleType()), fixedArgType(types.DoubleType())),
+			}
+		},
+	},
+	{
+		name: "cot",
+		signatures: func() []*types.FunctionSignature {
+			return []*types.FunctionSignature{
+				newSignature(fixedArgType(types.DoubleType()), fixedArgType(types.DoubleType())),
+			}
+		},
+	},
+	{
+		name: "coth",
+		signatures: func() []*types.FunctionSignature {
+			return []*types.FunctionSignature{
+				newSignature(fixedArgType(types.DoubleType()), fixedArgType(types.DoubleType())),
+			}
+		},
+	},
+	{
+		name: "csc",
+		signatures: func() []*types.FunctionSignature {
+			return []*types.FunctionSignature{
+				newSignature(fixedArgType(types.DoubleType()), fixedArgType(types.DoubleType())),
+			}
+		},
+	},
+	{
+		name: "csch",
+		signatures: func() []*types.FunctionSignature {
+			return []*types.FunctionSignature{
+				newSignature(fixedArgType(types.DoubleType()), fixedArgType(types.DoubleType())),
+			}
+		},
+	},
+	{
+		name: "sec",
+		signatures: func() []*types.FunctionSignature {
+			return []*types.FunctionSignature{
+				newSignature(fixedArgType(types.DoubleType()), fixedArgType(types.DoubleType())),
+			}
+		},
+	},
+	{
+		name: "sech",
+		signatures: func() []*types.FunctionSignature {
+			return []*types.FunctionSignature{
+				newSignature(fixedArgType(types.DoubleType()), fixedArgType(types.DoubleType())),
+			}
+		},
+	},
+	{
+		name: "date_bucket",
+		signatures: func() []*types.FunctionSignature {
+			return []*types.FunctionSignature{
+				newSignature(fixedArgType(types.DateType()), fixedArgType(types.DateType()), fixedArgType(types.IntervalType())),
+				newSignature(
+					fixedArgType(types.DateType()),
+					fixedArgType(types.DateType()), fixedArgType(types.IntervalType()), fixedArgType(types.DateType()),
+				),
+			}
+		},
+	},
+	{
+		name: "datetime_bucket",
+		signatures: func() []*types.FunctionSignature {
+			return []*types.FunctionSignature{
+				newSignature(fixedArgType(types.DatetimeType()), fixedArgType(types.DatetimeType()), fixedArgType(types.IntervalType())),
+				newSignature(
+					fixedArgType(types.DatetimeType()),
+					fixedArgType(types.DatetimeType()), fixedArgType(types.IntervalType()), fixedArgType(types.DatetimeType()),
+				),
+			}
+		},
+	},
+	{
+		name: "timestamp_bucket",
+		signatures: func() []*types.FunctionSignature {
+			return []*types.FunctionSignature{
+				newSignature(fixedArgType(types.TimestampType()), fixedArgType(types.TimestampType()), fixedArgType(types.IntervalType())),
+				newSignature(
+					fixedArgType(types.TimestampType()),
+					fixedArgType(types.TimestampType()), fixedArgType(types.IntervalType()), fixedArgType(types.TimestampType()),
+				),
+			}
+		},
+	},
+}
+
+func addExtraBuiltinFunctions(cat *types.SimpleCatalog) {
+	for _, fn := range extraBuiltinFunctions {
+		cat.AddFunction(types.NewFunction([]string{fn.name}, "", types.ScalarMode, fn.signatures()))
+	}
+}
+
+func newSignature(ret *types.FunctionArgumentType, args ...*types.FunctionArgumentType) *types.FunctionSignature {
+	return types.NewFunctionSignature(ret, args)
+}
+
+func templatedArgType(kind types.SignatureArgumentKind) *types.FunctionArgumentType {
+	return types.NewTemplatedFunctionArgumentType(
+		kind,
+		types.NewFunctionArgumentTypeOptions(types.RequiredArgumentCardinality),
+	)
+}
+
+func fixedArgType(typ types.Type) *types.FunctionArgumentType {
+	return types.NewFunctionArgumentType(
+		typ,
+		types.NewFunctionArgumentTypeOptions(types.RequiredArgumentCardinality),
+	)
+}
+
+// mustArrayType returns ARRAY<elem>. elem must not be an array type.
+func mustArrayType(elem types.Type) types.Type {
+	typ, err := types.NewArrayType(elem)
+	if err != nil {
+		panic(err)
+	}
+	return typ
+}
@@ -138,11 +138,7 @@ func ENDS_WITH(value, ends Value) (Value, error) {
 }
 
 func FORMAT(format string, args ...Value) (Value, error) {
-	result, err := parseFormat(format, args...)
-	if err != nil {
-		return nil, err
-	}
-	return StringValue(result), nil
+	return parseFormat(format, args...)
 }
 
 func FROM_BASE32(v string) (Value, error) {
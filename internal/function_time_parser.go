@@ -1185,6 +1185,16 @@ func parseTimeFormat(formatStr, targetStr string, typ TimeFormatType) (*time.Tim
 				targetIdx++
 			}
 		} else {
+			if targetIdx >= len(target) || target[targetIdx] != c {
+				var found string
+				if targetIdx < len(target) {
+					found = string(target[targetIdx])
+				}
+				return nil, fmt.Errorf(
+					"error parsing [%s] with format [%s]: mismatch between format character [%c] and string character [%s]",
+					string(target), formatStr, c, found,
+				)
+			}
 			formatIdx++
 			targetIdx++
 		}
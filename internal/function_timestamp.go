@@ -114,6 +114,17 @@ func TIMESTAMP_DIFF(a, b time.Time, part string) (Value, error) {
 	}
 }
 
+func TIMESTAMP_BUCKET(t time.Time, width *IntervalValue, origin time.Time) (Value, error) {
+	if width.Years != 0 || width.Months != 0 {
+		return nil, fmt.Errorf("TIMESTAMP_BUCKET: bucket width must not have Y-M parts")
+	}
+	bucket, err := timeBucket(t, width, origin)
+	if err != nil {
+		return nil, fmt.Errorf("TIMESTAMP_BUCKET: %w", err)
+	}
+	return TimestampValue(bucket), nil
+}
+
 func TIMESTAMP_TRUNC(t time.Time, part, zone string) (Value, error) {
 	loc, err := toLocation(zone)
 	if err != nil {
@@ -229,16 +240,40 @@ func PARSE_TIMESTAMP_WITH_TIMEZONE(format, date, zone string) (Value, error) {
 	return TimestampValue(modified), nil
 }
 
+// supported TIMESTAMP range is 0001-01-01 00:00:00 to 9999-12-31 23:59:59.999999 UTC.
+const (
+	minTimestampUnixMicros int64 = -62135596800000000
+	maxTimestampUnixMicros int64 = 253402300799999999
+)
+
+// validateUnixTimestampRange checks the range before converting to microseconds
+// so that the multiplication never overflows.
+func validateUnixTimestampRange(funcName string, v int64, microsPerUnit int64) error {
+	if v < minTimestampUnixMicros/microsPerUnit || maxTimestampUnixMicros/microsPerUnit < v {
+		return fmt.Errorf("%s: timestamp value %d is out of range", funcName, v)
+	}
+	return nil
+}
+
 func TIMESTAMP_SECONDS(sec int64) (Value, error) {
+	if err := validateUnixTimestampRange("TIMESTAMP_SECONDS", sec, 1000000); err != nil {
+		return nil, err
+	}
 	return TimestampValue(time.Unix(sec, 0)), nil
 }
 
-func TIMESTAMP_MILLIS(sec int64) (Value, error) {
-	return TimestampValue(time.UnixMicro(sec * 1000)), nil
+func TIMESTAMP_MILLIS(millisec int64) (Value, error) {
+	if err := validateUnixTimestampRange("TIMESTAMP_MILLIS", millisec, 1000); err != nil {
+		return nil, err
+	}
+	return TimestampValue(time.UnixMicro(millisec * 1000)), nil
 }
 
-func TIMESTAMP_MICROS(sec int64) (Value, error) {
-	return TimestampValue(time.UnixMicro(sec)), nil
+func TIMESTAMP_MICROS(microsec int64) (Value, error) {
+	if err := validateUnixTimestampRange("TIMESTAMP_MICROS", microsec, 1); err != nil {
+		return nil, err
+	}
+	return TimestampValue(time.UnixMicro(microsec)), nil
 }
 
 func UNIX_SECONDS(t time.Time) (Value, error) {
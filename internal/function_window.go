@@ -91,7 +91,7 @@ func (f *WINDOW_AVG) Done(agg *WindowFuncAggregatedStatus) (Value, error) {
 			return nil
 		}
 		var (
-			sum      Value
+			sum      *floatSum
 			valueMap = map[string]struct{}{}
 		)
 		for _, value := range values[start : end+1] {
@@ -108,28 +108,19 @@ func (f *WINDOW_AVG) Done(agg *WindowFuncAggregatedStatus) (Value, error) {
 				}
 				valueMap[key] = struct{}{}
 			}
+			f64, err := value.ToFloat64()
+			if err != nil {
+				return err
+			}
 			if sum == nil {
-				f64, err := value.ToFloat64()
-				if err != nil {
-					return err
-				}
-				sum = FloatValue(f64)
-			} else {
-				added, err := sum.Add(value)
-				if err != nil {
-					return err
-				}
-				sum = added
+				sum = &floatSum{}
 			}
+			sum.add(f64)
 		}
 		if sum == nil {
 			return nil
 		}
-		ret, err := sum.Div(FloatValue(float64(len(values[start : end+1]))))
-		if err != nil {
-			return err
-		}
-		avg = ret
+		avg = FloatValue(sum.value() / float64(len(values[start:end+1])))
 		return nil
 	}); err != nil {
 		return nil, err
@@ -345,7 +336,10 @@ func (f *WINDOW_SUM) Step(v Value, opt *WindowFuncStatus, agg *WindowFuncAggrega
 }
 
 func (f *WINDOW_SUM) Done(agg *WindowFuncAggregatedStatus) (Value, error) {
-	var sum Value
+	var (
+		sum  Value
+		fsum *floatSum
+	)
 	if err := agg.Done(func(values []Value, start, end int) error {
 		valueMap := map[string]struct{}{}
 		for _, value := range values[start : end+1] {
@@ -362,6 +356,13 @@ func (f *WINDOW_SUM) Done(agg *WindowFuncAggregatedStatus) (Value, error) {
 				}
 				valueMap[key] = struct{}{}
 			}
+			if fv, ok := value.(FloatValue); ok {
+				if fsum == nil {
+					fsum = &floatSum{}
+				}
+				fsum.add(float64(fv))
+				continue
+			}
 			if sum == nil {
 				sum = value
 			} else {
@@ -376,6 +377,9 @@ func (f *WINDOW_SUM) Done(agg *WindowFuncAggregatedStatus) (Value, error) {
 	}); err != nil {
 		return nil, err
 	}
+	if fsum != nil {
+		return FloatValue(fsum.value()), nil
+	}
 	return sum, nil
 }
 
@@ -476,7 +480,7 @@ func (f *WINDOW_NTH_VALUE) Done(agg *WindowFuncAggregatedStatus) (Value, error)
 			return nil
 		}
 		num := f.num - 1
-		if 0 <= f.num && f.num < int64(len(filteredValues)) {
+		if 0 <= num && num < int64(len(filteredValues)) {
 			nthValue = filteredValues[num]
 		}
 		return nil
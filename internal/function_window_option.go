@@ -434,11 +434,11 @@ func (s *WindowFuncAggregatedStatus) Done(cb func([]Value, int, int) error) erro
 		})
 	}
 	s.SortedValues = sortedValues
-	start, err := s.getIndexFromBoundary(s.Start)
+	start, err := s.getIndexFromBoundary(s.Start, true)
 	if err != nil {
 		return fmt.Errorf("failed to get start index: %w", err)
 	}
-	end, err := s.getIndexFromBoundary(s.End)
+	end, err := s.getIndexFromBoundary(s.End, false)
 	if err != nil {
 		return fmt.Errorf("failed to get end index: %w", err)
 	}
@@ -455,6 +455,10 @@ func (s *WindowFuncAggregatedStatus) Done(cb func([]Value, int, int) error) erro
 	if end >= len(resultValues) {
 		end = len(resultValues) - 1
 	}
+	if start > end {
+		// the frame is empty for the current row.
+		return nil
+	}
 	return cb(resultValues, start, end)
 }
 
@@ -477,12 +481,12 @@ func (s *WindowFuncAggregatedStatus) Partition() string {
 	return s.PartitionedValues[s.RowID-1].Partition
 }
 
-func (s *WindowFuncAggregatedStatus) getIndexFromBoundary(boundary *WindowBoundary) (int, error) {
+func (s *WindowFuncAggregatedStatus) getIndexFromBoundary(boundary *WindowBoundary, isStart bool) (int, error) {
 	switch s.FrameUnit {
 	case WindowFrameUnitRows:
 		return s.getIndexFromBoundaryByRows(boundary)
 	case WindowFrameUnitRange:
-		return s.getIndexFromBoundaryByRange(boundary)
+		return s.getIndexFromBoundaryByRange(boundary, isStart)
 	default:
 		return s.currentIndexByRows()
 	}
@@ -537,7 +541,11 @@ func (s *WindowFuncAggregatedStatus) partitionedCurrentIndexByRows() (int, error
 	return 0, fmt.Errorf("failed to find current index")
 }
 
-func (s *WindowFuncAggregatedStatus) getIndexFromBoundaryByRange(boundary *WindowBoundary) (int, error) {
+// getIndexFromBoundaryByRange resolves a range boundary to an index of the sorted values.
+// The start boundary resolves to the first row whose value is in range and the end boundary to the last one,
+// so start is greater than end when no row is in range.
+func (s *WindowFuncAggregatedStatus) getIndexFromBoundaryByRange(boundary *WindowBoundary, isStart bool) (int, error) {
+	var rangeValue Value
 	switch boundary.Type {
 	case WindowUnboundedPrecedingType:
 		return 0, nil
@@ -548,7 +556,7 @@ func (s *WindowFuncAggregatedStatus) getIndexFromBoundaryByRange(boundary *Windo
 		if err != nil {
 			return 0, err
 		}
-		return s.lookupMaxIndexFromRangeValue(value)
+		rangeValue = value
 	case WindowOffsetPrecedingType:
 		value, err := s.currentRangeValue()
 		if err != nil {
@@ -558,7 +566,7 @@ func (s *WindowFuncAggregatedStatus) getIndexFromBoundaryByRange(boundary *Windo
 		if err != nil {
 			return 0, err
 		}
-		return s.lookupMinIndexFromRangeValue(sub)
+		rangeValue = sub
 	case WindowOffsetFollowingType:
 		value, err := s.currentRangeValue()
 		if err != nil {
@@ -568,9 +576,14 @@ func (s *WindowFuncAggregatedStatus) getIndexFromBoundaryByRange(boundary *Windo
 		if err != nil {
 			return 0, err
 		}
-		return s.lookupMaxIndexFromRangeValue(add)
+		rangeValue = add
+	default:
+		return 0, fmt.Errorf("unsupported boundary type %d", boundary.Type)
 	}
-	return 0, fmt.Errorf("unsupported boundary type %d", boundary.Type)
+	if isStart {
+		return s.lookupMinIndexFromRangeValue(rangeValue)
+	}
+	return s.lookupMaxIndexFromRangeValue(rangeValue)
 }
 
 func (s *WindowFuncAggregatedStatus) currentRangeValue() (Value, error) {
@@ -595,7 +608,7 @@ func (s *WindowFuncAggregatedStatus) partitionedCurrentRangeValue() (Value, erro
 }
 
 func (s *WindowFuncAggregatedStatus) lookupMinIndexFromRangeValue(rangeValue Value) (int, error) {
-	minIndex := -1
+	minIndex := len(s.SortedValues)
 	for idx := len(s.SortedValues) - 1; idx >= 0; idx-- {
 		value := s.SortedValues[idx]
 		if len(value.OrderBy) == 0 {
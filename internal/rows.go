@@ -6,6 +6,7 @@ import (
 	"database/sql/driver"
 	"fmt"
 	"io"
+	"math/big"
 	"reflect"
 	"time"
 
@@ -14,10 +15,14 @@ import (
 )
 
 type Rows struct {
+	ctx     context.Context
 	rows    *sql.Rows
 	conn    *Conn
 	columns []*ColumnSpec
 	actions []StmtAction
+
+	// isRatNumericMode makes NUMERIC and BIGNUMERIC values scanned as *big.Rat instead of string.
+	isRatNumericMode bool
 }
 
 func (r *Rows) ChangedCatalog() *ChangedCatalog {
@@ -41,6 +46,31 @@ func (r *Rows) ColumnTypeDatabaseTypeName(i int) string {
 	return string(encodedType)
 }
 
+// ColumnTypeScanType returns the type of the value that Next sets to dest for the column.
+func (r *Rows) ColumnTypeScanType(i int) reflect.Type {
+	return r.scanType(r.columns[i].Type)
+}
+
+func (r *Rows) scanType(typ *Type) reflect.Type {
+	switch types.TypeKind(typ.Kind) {
+	case types.NUMERIC, types.BIG_NUMERIC:
+		if r.isRatNumericMode {
+			return reflect.TypeOf(&big.Rat{})
+		}
+	case types.INT32, types.INT64, types.UINT32, types.UINT64:
+		return reflect.TypeOf(int64(0))
+	case types.BOOL:
+		return reflect.TypeOf(false)
+	case types.FLOAT, types.DOUBLE:
+		return reflect.TypeOf(float64(0))
+	case types.STRUCT:
+		return reflect.TypeOf([]map[string]interface{}{})
+	case types.ARRAY:
+		return reflect.TypeOf([]interface{}{})
+	}
+	return reflect.TypeOf("")
+}
+
 func (r *Rows) Close() (e error) {
 	defer func() {
 		eg := new(ErrorGroup)
@@ -66,10 +96,81 @@ func (r *Rows) columnTypes() []*Type {
 	return ret
 }
 
+func (r *Rows) zetasqlColumnTypes() ([]types.Type, error) {
+	ret := make([]types.Type, 0, len(r.columns))
+	for _, col := range r.columns {
+		t, err := col.Type.ToZetaSQLType()
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, t)
+	}
+	return ret, nil
+}
+
+// nextValues reads the next row as the values of typs.
+// It returns nil if there are no more rows.
+func (r *Rows) nextValues(typs []types.Type) ([]Value, error) {
+	if r.rows == nil {
+		return nil, nil
+	}
+	if !r.rows.Next() {
+		return nil, r.rows.Err()
+	}
+	scanned := make([]interface{}, 0, len(typs))
+	for range typs {
+		var v interface{}
+		scanned = append(scanned, &v)
+	}
+	if err := r.rows.Scan(scanned...); err != nil {
+		return nil, err
+	}
+	values := make([]Value, 0, len(typs))
+	for idx, typ := range typs {
+		decodedValue, err := DecodeValue(*(scanned[idx].(*interface{})))
+		if err != nil {
+			return nil, err
+		}
+		value, err := CastValue(typ, decodedValue)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+// firstValue returns the value of the first column in the first row and the type of the column.
+// The value is nil if there are no rows.
+func (r *Rows) firstValue() (Value, types.Type, error) {
+	typs, err := r.zetasqlColumnTypes()
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(typs) == 0 {
+		return nil, nil, fmt.Errorf("failed to get value: no columns")
+	}
+	values, err := r.nextValues(typs)
+	if err != nil {
+		return nil, nil, err
+	}
+	if values == nil {
+		return nil, typs[0], nil
+	}
+	return values[0], typs[0], nil
+}
+
 func (r *Rows) Next(dest []driver.Value) error {
 	if r.rows == nil {
 		return io.EOF
 	}
+	if r.ctx != nil {
+		// stop reading rows as soon as the context passed to QueryContext is canceled
+		// even if the underlying rows have not noticed it yet.
+		if err := r.ctx.Err(); err != nil {
+			return err
+		}
+	}
 	if !r.rows.Next() {
 		if err := r.rows.Err(); err != nil {
 			return err
@@ -240,13 +341,15 @@ func (r *Rows) assignInterfaceValue(src Value, dst reflect.Value, typ *Type) err
 			return err
 		}
 		dst.Set(reflect.ValueOf(s))
-	case types.NUMERIC:
-		s, err := src.ToString()
-		if err != nil {
-			return err
+	case types.NUMERIC, types.BIG_NUMERIC:
+		if r.isRatNumericMode {
+			rat, err := src.ToRat()
+			if err != nil {
+				return err
+			}
+			dst.Set(reflect.ValueOf(rat))
+			return nil
 		}
-		dst.Set(reflect.ValueOf(s))
-	case types.BIG_NUMERIC:
 		s, err := src.ToString()
 		if err != nil {
 			return err
@@ -285,6 +388,12 @@ func (r *Rows) assignInterfaceValue(src Value, dst reflect.Value, typ *Type) err
 			return err
 		}
 		dst.Set(reflect.ValueOf(s))
+	case types.GEOGRAPHY:
+		s, err := src.ToString()
+		if err != nil {
+			return err
+		}
+		dst.Set(reflect.ValueOf(s))
 	case types.JSON:
 		v, err := src.ToJSON()
 		if err != nil {
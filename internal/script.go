@@ -0,0 +1,930 @@
+package internal
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/goccy/go-zetasql"
+	parsed_ast "github.com/goccy/go-zetasql/ast"
+	"github.com/goccy/go-zetasql/types"
+)
+
+// unrecognizedNamePattern matches the error of ZetaSQL for the name which is neither a column nor a table.
+// The location points to the first identifier of the path expression.
+var unrecognizedNamePattern = regexp.MustCompile(`Unrecognized name: .* \[at (\d+):(\d+)\]`)
+
+// scriptVariable is the variable declared by DECLARE statement.
+// The references to the variable are replaced with the query parameter named param.
+type scriptVariable struct {
+	name  string
+	param string
+	typ   types.Type
+	value Value
+}
+
+// scriptJump is raised by BREAK ( LEAVE ) or CONTINUE ( ITERATE ) statement
+// and is propagated until the loop or the block specified by label.
+type scriptJump struct {
+	keyword    parsed_ast.BreakContinueKeyword
+	isContinue bool
+	label      string
+}
+
+func newScriptJump(keyword parsed_ast.BreakContinueKeyword, label *parsed_ast.LabelNode) *scriptJump {
+	return &scriptJump{
+		keyword:    keyword,
+		isContinue: keyword == parsed_ast.ContinueKeyword || keyword == parsed_ast.IterateKeyword,
+		label:      labelName(label),
+	}
+}
+
+// unhandledError returns the error for the jump which reached the top level of the script.
+func (j *scriptJump) unhandledError() error {
+	if j.label != "" {
+		return fmt.Errorf("%s target label %s is not found", j.keyword, j.label)
+	}
+	return fmt.Errorf("%s is only allowed inside a loop", j.keyword)
+}
+
+// targets reports whether the jump is handled by the loop or the block named label.
+func (j *scriptJump) targets(label string) bool {
+	return j.label == "" || j.label == label
+}
+
+// handleLoopJump handles jump raised in the body of the loop named label.
+// It reports whether the loop continues and returns the jump propagated to the outer statements.
+func handleLoopJump(jump *scriptJump, label string) (bool, *scriptJump) {
+	if jump == nil {
+		return true, nil
+	}
+	if !jump.targets(label) {
+		return false, jump
+	}
+	return jump.isContinue, nil
+}
+
+func labelName(label *parsed_ast.LabelNode) string {
+	if label == nil {
+		return ""
+	}
+	return strings.ToLower(label.Name().Name())
+}
+
+func statementList(list *parsed_ast.StatementListNode) []parsed_ast.StatementNode {
+	if list == nil {
+		return nil
+	}
+	return list.StatementList()
+}
+
+// isScriptStmt reports whether stmt is the procedural statement interpreted by ScriptStmtAction.
+func isScriptStmt(stmt parsed_ast.StatementNode) bool {
+	switch stmt.(type) {
+	case *parsed_ast.VariableDeclarationNode,
+		*parsed_ast.SingleAssignmentNode,
+		*parsed_ast.AssignmentFromStructNode,
+		*parsed_ast.ExecuteImmediateStatementNode,
+		*parsed_ast.IfStatementNode,
+		*parsed_ast.WhileStatementNode,
+		*parsed_ast.RepeatStatementNode,
+		*parsed_ast.ForInStatementNode,
+		*parsed_ast.BreakStatementNode,
+		*parsed_ast.ContinueStatementNode:
+		return true
+	}
+	return false
+}
+
+// ScriptStmtAction runs the script which has procedural statements like DECLARE, SET, IF and loops.
+// The control flow depends on the results of the preceding statements,
+// so each statement is analyzed and executed in order when the action is executed.
+type ScriptStmtAction struct {
+	analyzer    *Analyzer
+	query       string
+	args        []driver.NamedValue
+	stmts       []parsed_ast.StatementNode
+	variables   map[string]*scriptVariable
+	variableNum int
+	actions     []StmtAction
+	rows        *Rows
+	result      driver.Result
+}
+
+func newScriptStmtAction(analyzer *Analyzer, query string, args []driver.NamedValue, stmts []parsed_ast.StatementNode) *ScriptStmtAction {
+	return &ScriptStmtAction{
+		analyzer:  analyzer,
+		query:     query,
+		args:      args,
+		stmts:     stmts,
+		variables: map[string]*scriptVariable{},
+	}
+}
+
+func (a *ScriptStmtAction) Prepare(ctx context.Context, conn *Conn) (driver.Stmt, error) {
+	return nil, fmt.Errorf("unsupported prepare for scripting statements")
+}
+
+func (a *ScriptStmtAction) run(ctx context.Context, conn *Conn) error {
+	// the queries are rewritten to refer the variables, so they must not be cached.
+	ctx = withoutStmtCache(ctx)
+	for idx, stmt := range a.stmts {
+		jump, err := a.runStmt(ctx, conn, stmt)
+		if err == nil && jump != nil {
+			err = jump.unhandledError()
+		}
+		if err != nil {
+			if len(a.stmts) > 1 {
+				return newScriptStmtPosition(a.query, idx, stmt).wrapError(err)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *ScriptStmtAction) ExecContext(ctx context.Context, conn *Conn) (driver.Result, error) {
+	if err := a.run(ctx, conn); err != nil {
+		return nil, err
+	}
+	if err := a.closeRows(); err != nil {
+		return nil, err
+	}
+	if a.result == nil {
+		return &Result{conn: conn}, nil
+	}
+	return a.result, nil
+}
+
+// QueryContext returns the result of the last query statement in the script.
+func (a *ScriptStmtAction) QueryContext(ctx context.Context, conn *Conn) (*Rows, error) {
+	if err := a.run(ctx, conn); err != nil {
+		return nil, err
+	}
+	rows := a.rows
+	if rows == nil {
+		return &Rows{conn: conn}, nil
+	}
+	// the caller closes the rows and cleans up this action after that,
+	// so the action of the rows is cleaned up together with this action.
+	a.rows = nil
+	a.actions = append(a.actions, rows.actions...)
+	rows.actions = nil
+	return rows, nil
+}
+
+func (a *ScriptStmtAction) Args() []interface{} {
+	return nil
+}
+
+func (a *ScriptStmtAction) Cleanup(ctx context.Context, conn *Conn) error {
+	eg := new(ErrorGroup)
+	eg.Add(a.closeRows())
+	for _, action := range a.actions {
+		eg.Add(action.Cleanup(ctx, conn))
+	}
+	if eg.HasError() {
+		return eg
+	}
+	return nil
+}
+
+// cleanupActions cleans up the actions whose results are no longer used and returns err with the errors of them.
+func cleanupActions(ctx context.Context, conn *Conn, actions []StmtAction, err error) error {
+	eg := new(ErrorGroup)
+	for _, action := range actions {
+		eg.Add(action.Cleanup(ctx, conn))
+	}
+	if !eg.HasError() {
+		return err
+	}
+	if err == nil {
+		return eg
+	}
+	return fmt.Errorf("%w: failed to cleanup: %s", err, eg)
+}
+
+// outlivesStatement reports whether the cleanup of action must wait until the end of the script.
+// The cleanup of these actions drops the temporary table, view or function created by the statement.
+func outlivesStatement(action StmtAction) bool {
+	switch unwrapStmtAction(action).(type) {
+	case *CreateTableStmtAction, *CreateViewStmtAction, *CreateFunctionStmtAction:
+		return true
+	}
+	return false
+}
+
+// unwrapStmtAction returns the action wrapped to report the position of the statement in the script.
+func unwrapStmtAction(action StmtAction) StmtAction {
+	if wrapped, ok := action.(*scriptStmtAction); ok {
+		return wrapped.StmtAction
+	}
+	return action
+}
+
+func (a *ScriptStmtAction) closeRows() error {
+	if a.rows == nil {
+		return nil
+	}
+	rows := a.rows
+	a.rows = nil
+	return rows.Close()
+}
+
+func (a *ScriptStmtAction) runStmts(ctx context.Context, conn *Conn, stmts []parsed_ast.StatementNode) (*scriptJump, error) {
+	for _, stmt := range stmts {
+		// don't run the remaining statements after the context is canceled, especially in the loops.
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		jump, err := a.runStmt(ctx, conn, stmt)
+		if err != nil {
+			return nil, err
+		}
+		if jump != nil {
+			return jump, nil
+		}
+	}
+	return nil, nil
+}
+
+func (a *ScriptStmtAction) runStmt(ctx context.Context, conn *Conn, stmt parsed_ast.StatementNode) (*scriptJump, error) {
+	switch s := stmt.(type) {
+	case *parsed_ast.VariableDeclarationNode:
+		return nil, a.declare(ctx, conn, s)
+	case *parsed_ast.SingleAssignmentNode:
+		return nil, a.assign(ctx, conn, s)
+	case *parsed_ast.AssignmentFromStructNode:
+		return nil, a.assignFromStruct(ctx, conn, s)
+	case *parsed_ast.ExecuteImmediateStatementNode:
+		return nil, a.executeImmediate(ctx, conn, s)
+	case *parsed_ast.IfStatementNode:
+		return a.runIf(ctx, conn, s)
+	case *parsed_ast.WhileStatementNode:
+		return a.runWhile(ctx, conn, s)
+	case *parsed_ast.RepeatStatementNode:
+		return a.runRepeat(ctx, conn, s)
+	case *parsed_ast.ForInStatementNode:
+		return a.runFor(ctx, conn, s)
+	case *parsed_ast.BreakStatementNode:
+		return newScriptJump(s.Keyword(), s.Label()), nil
+	case *parsed_ast.ContinueStatementNode:
+		return newScriptJump(s.Keyword(), s.Label()), nil
+	case *parsed_ast.BeginEndBlockNode:
+		return a.runBlock(ctx, conn, s)
+	}
+	return nil, a.runSQL(ctx, conn, stmt)
+}
+
+func (a *ScriptStmtAction) declare(ctx context.Context, conn *Conn, node *parsed_ast.VariableDeclarationNode) error {
+	expr := "NULL"
+	if defaultValue := node.DefaultValue(); defaultValue != nil {
+		expr = a.nodeText(defaultValue)
+	}
+	if typ := node.Type(); typ != nil {
+		expr = fmt.Sprintf("CAST(%s AS %s)", expr, a.nodeText(typ))
+	}
+	value, typ, err := a.eval(ctx, conn, expr)
+	if err != nil {
+		return fmt.Errorf("failed to declare variable: %w", err)
+	}
+	for _, ident := range node.VariableList().IdentifierList() {
+		name := strings.ToLower(ident.Name())
+		if _, exists := a.variables[name]; exists {
+			return fmt.Errorf("variable %s is already declared", ident.Name())
+		}
+		a.variables[name] = a.newVariable(ident.Name(), typ, value)
+	}
+	return nil
+}
+
+func (a *ScriptStmtAction) newVariable(name string, typ types.Type, value Value) *scriptVariable {
+	variable := &scriptVariable{
+		name:  name,
+		param: fmt.Sprintf("zetasqlite_variable_%d", a.variableNum),
+		typ:   typ,
+		value: value,
+	}
+	a.variableNum++
+	return variable
+}
+
+func (a *ScriptStmtAction) assign(ctx context.Context, conn *Conn, node *parsed_ast.SingleAssignmentNode) error {
+	value, _, err := a.eval(ctx, conn, a.nodeText(node.Expression()))
+	if err != nil {
+		return fmt.Errorf("failed to assign value to %s: %w", node.Variable().Name(), err)
+	}
+	return a.setVariable(node.Variable().Name(), value)
+}
+
+// assignFromStruct assigns the fields of the STRUCT value to the variables in order like SET (a, b) = (1, 2).
+func (a *ScriptStmtAction) assignFromStruct(ctx context.Context, conn *Conn, node *parsed_ast.AssignmentFromStructNode) error {
+	value, typ, err := a.eval(ctx, conn, a.nodeText(node.StructExpression()))
+	if err != nil {
+		return fmt.Errorf("failed to assign values: %w", err)
+	}
+	if typ.Kind() != types.STRUCT {
+		return fmt.Errorf("assigned value must be STRUCT type but got %s", typ.TypeName(types.ProductExternal))
+	}
+	idents := node.Variables().IdentifierList()
+	if typ.AsStruct().NumFields() != len(idents) {
+		return fmt.Errorf(
+			"number of fields %d of assigned value doesn't match number of variables %d",
+			typ.AsStruct().NumFields(), len(idents),
+		)
+	}
+	var fields []Value
+	if value != nil {
+		sv, err := value.ToStruct()
+		if err != nil {
+			return err
+		}
+		fields = sv.values
+	}
+	for idx, ident := range idents {
+		var field Value
+		if fields != nil {
+			field = fields[idx]
+		}
+		if err := a.setVariable(ident.Name(), field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *ScriptStmtAction) setVariable(name string, value Value) error {
+	variable, exists := a.variables[strings.ToLower(name)]
+	if !exists {
+		return fmt.Errorf("undeclared variable %s", name)
+	}
+	casted, err := CastValue(variable.typ, value)
+	if err != nil {
+		return fmt.Errorf("failed to assign value to %s: %w", variable.name, err)
+	}
+	variable.value = casted
+	return nil
+}
+
+// executeImmediate runs the statement built dynamically by EXECUTE IMMEDIATE.
+// The arguments of USING clause are bound to the statement as the query parameters,
+// and the result of the query is assigned to the variables of INTO clause.
+func (a *ScriptStmtAction) executeImmediate(ctx context.Context, conn *Conn, node *parsed_ast.ExecuteImmediateStatementNode) error {
+	sqlValue, sqlType, err := a.eval(ctx, conn, a.nodeText(node.SQL()))
+	if err != nil {
+		return fmt.Errorf("failed to evaluate EXECUTE IMMEDIATE statement: %w", err)
+	}
+	if sqlType.Kind() != types.STRING {
+		return fmt.Errorf("EXECUTE IMMEDIATE statement must be STRING type but got %s", sqlType.TypeName(types.ProductExternal))
+	}
+	if sqlValue == nil {
+		return fmt.Errorf("EXECUTE IMMEDIATE statement must not be NULL")
+	}
+	query, err := sqlValue.ToString()
+	if err != nil {
+		return err
+	}
+	var (
+		args     []driver.NamedValue
+		argTypes []types.Type
+	)
+	if using := node.UsingClause(); using != nil {
+		for idx, arg := range using.Arguments() {
+			value, typ, err := a.eval(ctx, conn, a.nodeText(arg.Expression()))
+			if err != nil {
+				return fmt.Errorf("failed to evaluate USING argument: %w", err)
+			}
+			namedValue := driver.NamedValue{Ordinal: idx + 1, Value: value}
+			if alias := arg.Alias(); alias != nil {
+				namedValue.Name = alias.Name()
+			}
+			if idx > 0 && (namedValue.Name == "") != (args[0].Name == "") {
+				return fmt.Errorf("USING arguments must be either all named or all positional")
+			}
+			args = append(args, namedValue)
+			argTypes = append(argTypes, typ)
+		}
+	}
+	actions, err := a.analyzeDynamicSQL(ctx, conn, query, args, argTypes)
+	if err != nil {
+		return err
+	}
+	if into := node.IntoClause(); into != nil {
+		return a.assignInto(ctx, conn, actions, into.Identifiers().IdentifierList())
+	}
+	return a.runActions(ctx, conn, actions)
+}
+
+// assignInto assigns the columns of the single row returned by the query to the variables.
+// The variables are set to NULL if the query returns no rows.
+func (a *ScriptStmtAction) assignInto(ctx context.Context, conn *Conn, actions []StmtAction, idents []*parsed_ast.IdentifierNode) (e error) {
+	defer func() { e = cleanupActions(ctx, conn, actions, e) }()
+
+	if len(actions) != 1 {
+		return fmt.Errorf("EXECUTE IMMEDIATE with INTO clause requires a single query")
+	}
+	if _, ok := actions[0].(*QueryStmtAction); !ok {
+		return fmt.Errorf("EXECUTE IMMEDIATE with INTO clause requires a query")
+	}
+	rows, err := actions[0].QueryContext(ctx, conn)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	typs, err := rows.zetasqlColumnTypes()
+	if err != nil {
+		return err
+	}
+	if len(typs) != len(idents) {
+		return fmt.Errorf(
+			"number of columns %d returned by EXECUTE IMMEDIATE doesn't match number of variables %d",
+			len(typs), len(idents),
+		)
+	}
+	values, err := rows.nextValues(typs)
+	if err != nil {
+		return err
+	}
+	if values != nil {
+		next, err := rows.nextValues(typs)
+		if err != nil {
+			return err
+		}
+		if next != nil {
+			return fmt.Errorf("EXECUTE IMMEDIATE with INTO clause returned more than one row")
+		}
+	}
+	for idx, ident := range idents {
+		var value Value
+		if values != nil {
+			value = values[idx]
+		}
+		if err := a.setVariable(ident.Name(), value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *ScriptStmtAction) runIf(ctx context.Context, conn *Conn, node *parsed_ast.IfStatementNode) (*scriptJump, error) {
+	cond, err := a.evalCondition(ctx, conn, node.Condition())
+	if err != nil {
+		return nil, err
+	}
+	if cond {
+		return a.runStmts(ctx, conn, statementList(node.ThenList()))
+	}
+	if clauses := node.ElseifClauses(); clauses != nil {
+		for _, clause := range clauses.ElseifClauses() {
+			cond, err := a.evalCondition(ctx, conn, clause.Condition())
+			if err != nil {
+				return nil, err
+			}
+			if cond {
+				return a.runStmts(ctx, conn, statementList(clause.Body()))
+			}
+		}
+	}
+	return a.runStmts(ctx, conn, statementList(node.ElseList()))
+}
+
+// runWhile runs WHILE statement and LOOP statement which is parsed as WHILE statement without the condition.
+func (a *ScriptStmtAction) runWhile(ctx context.Context, conn *Conn, node *parsed_ast.WhileStatementNode) (*scriptJump, error) {
+	label := labelName(node.Label())
+	for {
+		if cond := node.Condition(); cond != nil {
+			ok, err := a.evalCondition(ctx, conn, cond)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				return nil, nil
+			}
+		}
+		jump, err := a.runStmts(ctx, conn, statementList(node.Body()))
+		if err != nil {
+			return nil, err
+		}
+		if next, jump := handleLoopJump(jump, label); !next {
+			return jump, nil
+		}
+	}
+}
+
+func (a *ScriptStmtAction) runRepeat(ctx context.Context, conn *Conn, node *parsed_ast.RepeatStatementNode) (*scriptJump, error) {
+	label := labelName(node.Label())
+	for {
+		jump, err := a.runStmts(ctx, conn, statementList(node.Body()))
+		if err != nil {
+			return nil, err
+		}
+		if next, jump := handleLoopJump(jump, label); !next {
+			return jump, nil
+		}
+		until, err := a.evalCondition(ctx, conn, node.UntilClause().Condition())
+		if err != nil {
+			return nil, err
+		}
+		if until {
+			return nil, nil
+		}
+	}
+}
+
+// runBlock runs BEGIN...END block. The variables declared in the block are removed at the end of the block.
+func (a *ScriptStmtAction) runBlock(ctx context.Context, conn *Conn, node *parsed_ast.BeginEndBlockNode) (*scriptJump, error) {
+	if node.HasExceptionHandler() {
+		return nil, fmt.Errorf("unsupported BEGIN...EXCEPTION...END block")
+	}
+	defer a.enterScope()()
+
+	jump, err := a.runStmts(ctx, conn, node.StatementList())
+	if err != nil {
+		return nil, err
+	}
+	if jump != nil && !jump.isContinue && jump.label != "" && jump.label == labelName(node.Label()) {
+		// LEAVE with the label of the block exits the block.
+		return nil, nil
+	}
+	return jump, nil
+}
+
+// runFor runs FOR...IN statement. The result of the query is read before running the body,
+// and each row is assigned to the variable as STRUCT value.
+func (a *ScriptStmtAction) runFor(ctx context.Context, conn *Conn, node *parsed_ast.ForInStatementNode) (*scriptJump, error) {
+	typ, rows, err := a.queryRows(ctx, conn, a.nodeText(node.Query()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to run FOR...IN query: %w", err)
+	}
+	defer a.enterScope()()
+
+	variable := a.newVariable(node.Variable().Name(), typ, nil)
+	// the variable is available only in the body of the loop.
+	a.variables[strings.ToLower(variable.name)] = variable
+
+	label := labelName(node.Label())
+	for _, row := range rows {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		variable.value = row
+		jump, err := a.runStmts(ctx, conn, statementList(node.Body()))
+		if err != nil {
+			return nil, err
+		}
+		if next, jump := handleLoopJump(jump, label); !next {
+			return jump, nil
+		}
+	}
+	return nil, nil
+}
+
+// enterScope starts the scope of the variables declared after that, and returns the function to end the scope.
+func (a *ScriptStmtAction) enterScope() func() {
+	outer := a.variables
+	a.variables = make(map[string]*scriptVariable, len(outer))
+	for name, variable := range outer {
+		a.variables[name] = variable
+	}
+	return func() { a.variables = outer }
+}
+
+// runSQL analyzes and executes the statement which isn't a procedural statement.
+func (a *ScriptStmtAction) runSQL(ctx context.Context, conn *Conn, stmt parsed_ast.StatementNode) error {
+	actions, err := a.analyze(ctx, conn, a.nodeText(stmt))
+	if err != nil {
+		return err
+	}
+	return a.runActions(ctx, conn, actions)
+}
+
+// runActions runs the actions of a statement in the script.
+// The actions are cleaned up as soon as their results are no longer used,
+// so that a long loop doesn't keep the actions of all iterations.
+func (a *ScriptStmtAction) runActions(ctx context.Context, conn *Conn, actions []StmtAction) error {
+	for idx, action := range actions {
+		if err := a.runAction(ctx, conn, action); err != nil {
+			return cleanupActions(ctx, conn, actions[idx+1:], err)
+		}
+	}
+	return nil
+}
+
+func (a *ScriptStmtAction) runAction(ctx context.Context, conn *Conn, action StmtAction) error {
+	switch unwrapStmtAction(action).(type) {
+	case *QueryStmtAction, *ExplainStmtAction:
+		// only the result of the last query is returned.
+		if err := a.closeRows(); err != nil {
+			return cleanupActions(ctx, conn, []StmtAction{action}, err)
+		}
+		rows, err := action.QueryContext(ctx, conn)
+		if err != nil {
+			return cleanupActions(ctx, conn, []StmtAction{action}, err)
+		}
+		// the action is cleaned up when the rows are closed.
+		rows.SetActions([]StmtAction{action})
+		a.rows = rows
+		return nil
+	}
+	result, err := action.ExecContext(ctx, conn)
+	if outlivesStatement(action) {
+		a.actions = append(a.actions, action)
+	} else {
+		err = cleanupActions(ctx, conn, []StmtAction{action}, err)
+	}
+	if err != nil {
+		return err
+	}
+	a.result = result
+	return nil
+}
+
+// eval evaluates the expression and returns the value and the type of it.
+func (a *ScriptStmtAction) eval(ctx context.Context, conn *Conn, expr string) (_ Value, _ types.Type, e error) {
+	actions, err := a.analyze(ctx, conn, fmt.Sprintf("SELECT %s", expr))
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() { e = cleanupActions(ctx, conn, actions, e) }()
+
+	if len(actions) != 1 {
+		return nil, nil, fmt.Errorf("unexpected expression %s", expr)
+	}
+	rows, err := actions[0].QueryContext(ctx, conn)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	return rows.firstValue()
+}
+
+// queryRows runs the query and returns the rows as STRUCT values and the type of them.
+func (a *ScriptStmtAction) queryRows(ctx context.Context, conn *Conn, query string) (_ types.Type, _ []Value, e error) {
+	actions, err := a.analyze(ctx, conn, query)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() { e = cleanupActions(ctx, conn, actions, e) }()
+
+	if len(actions) != 1 {
+		return nil, nil, fmt.Errorf("unexpected query %s", query)
+	}
+	rows, err := actions[0].QueryContext(ctx, conn)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	typs, err := rows.zetasqlColumnTypes()
+	if err != nil {
+		return nil, nil, err
+	}
+	fields := make([]*types.StructField, 0, len(typs))
+	for idx, typ := range typs {
+		fields = append(fields, types.NewStructField(rows.columns[idx].Name, typ))
+	}
+	structType, err := types.NewStructType(fields)
+	if err != nil {
+		return nil, nil, err
+	}
+	var ret []Value
+	for {
+		values, err := rows.nextValues(typs)
+		if err != nil {
+			return nil, nil, err
+		}
+		if values == nil {
+			break
+		}
+		row := &StructValue{m: map[string]Value{}}
+		for idx, value := range values {
+			name := rows.columns[idx].Name
+			row.keys = append(row.keys, name)
+			row.values = append(row.values, value)
+			row.m[name] = value
+		}
+		ret = append(ret, row)
+	}
+	return structType, ret, nil
+}
+
+func (a *ScriptStmtAction) evalCondition(ctx context.Context, conn *Conn, expr parsed_ast.ExpressionNode) (bool, error) {
+	value, typ, err := a.eval(ctx, conn, a.nodeText(expr))
+	if err != nil {
+		return false, fmt.Errorf("failed to evaluate condition: %w", err)
+	}
+	if typ.Kind() != types.BOOL {
+		return false, fmt.Errorf("condition must be BOOL type but got %s", typ.TypeName(types.ProductExternal))
+	}
+	if value == nil {
+		// NULL condition is treated as FALSE.
+		return false, nil
+	}
+	return value.ToBool()
+}
+
+// analyze analyzes the query with the variables of the script and returns the actions for it.
+func (a *ScriptStmtAction) analyze(ctx context.Context, conn *Conn, query string) ([]StmtAction, error) {
+	opt := a.analyzer.opt
+	defer opt.ClearQueryParameters()
+	for _, variable := range a.variables {
+		if err := opt.AddQueryParameter(variable.param, variable.typ); err != nil {
+			return nil, fmt.Errorf("failed to add variable %s: %w", variable.name, err)
+		}
+	}
+	query, args, err := a.bindVariables(ctx, conn, query)
+	if err != nil {
+		return nil, err
+	}
+	return a.buildActions(ctx, conn, query, args)
+}
+
+// analyzeDynamicSQL analyzes the query of EXECUTE IMMEDIATE statement with the arguments of USING clause.
+// The query can't refer the variables of the script directly.
+func (a *ScriptStmtAction) analyzeDynamicSQL(ctx context.Context, conn *Conn, query string, args []driver.NamedValue, argTypes []types.Type) ([]StmtAction, error) {
+	opt := a.analyzer.opt
+	defer opt.ClearQueryParameters()
+	defer opt.ClearPositionalQueyParameters()
+	if len(args) > 0 && args[0].Name == "" {
+		// the types of the positional parameters can be declared only if undeclared parameters are not allowed.
+		opt.SetAllowUndeclaredParameters(false)
+		defer opt.SetAllowUndeclaredParameters(true)
+		for _, typ := range argTypes {
+			if err := opt.AddPositionalQueryParameter(typ); err != nil {
+				return nil, fmt.Errorf("failed to add USING argument: %w", err)
+			}
+		}
+	} else {
+		for idx, arg := range args {
+			if err := opt.AddQueryParameter(arg.Name, argTypes[idx]); err != nil {
+				return nil, fmt.Errorf("failed to add USING argument %s: %w", arg.Name, err)
+			}
+		}
+	}
+	return a.buildActions(ctx, conn, query, args)
+}
+
+// buildActions analyzes the query and builds the actions for it.
+// The caller must clean up the returned actions.
+func (a *ScriptStmtAction) buildActions(ctx context.Context, conn *Conn, query string, args []driver.NamedValue) ([]StmtAction, error) {
+	actionFuncs, err := a.analyzer.Analyze(ctx, conn, query, args)
+	if err != nil {
+		return nil, err
+	}
+	actions := make([]StmtAction, 0, len(actionFuncs))
+	for _, actionFunc := range actionFuncs {
+		action, err := actionFunc()
+		if err != nil {
+			return nil, cleanupActions(ctx, conn, actions, err)
+		}
+		actions = append(actions, action)
+	}
+	return actions, nil
+}
+
+// bindVariables replaces the references to the variables in query with the query parameters
+// and returns the rewritten query and the arguments including the values of the variables.
+// A name is a variable only if ZetaSQL can't resolve it, because a column takes precedence over a variable.
+func (a *ScriptStmtAction) bindVariables(ctx context.Context, conn *Conn, query string) (string, []driver.NamedValue, error) {
+	if len(a.variables) == 0 {
+		return query, a.args, nil
+	}
+	// the variables are bound as named parameters, which can't be used together with positional parameters.
+	for _, arg := range a.args {
+		if arg.Name == "" {
+			return "", nil, fmt.Errorf("positional parameters can't be used in the script declaring variables, use named parameters instead")
+		}
+	}
+	if err := a.analyzer.catalog.Sync(ctx, conn); err != nil {
+		return "", nil, fmt.Errorf("failed to sync catalog: %w", err)
+	}
+	opt := a.analyzer.opt
+	defer opt.SetParameterMode(opt.ParameterMode())
+	opt.SetParameterMode(zetasql.ParameterNamed)
+
+	var bound []*scriptVariable
+	for {
+		stmt, err := zetasql.ParseStatement(query, opt.ParserOptions())
+		if err != nil {
+			// the syntax error is reported by analyzing the query again.
+			break
+		}
+		if _, err = zetasql.AnalyzeStatementFromParserAST(query, stmt, a.analyzer.catalog, opt); err == nil {
+			break
+		}
+		if rewritten, ok := offsetBareArrayElement(query, stmt, err); ok {
+			// the array element access is reported before the variables after it.
+			query = rewritten
+			continue
+		}
+		start, end, variable := a.findVariableReference(query, err)
+		if variable == nil {
+			// the other error is reported by analyzing the query again.
+			break
+		}
+		param := fmt.Sprintf("@%s", variable.param)
+		if end < len(query) && query[end] == '.' {
+			// access to the field of the struct variable.
+			param = fmt.Sprintf("(%s)", param)
+		}
+		query = query[:start] + param + query[end:]
+		bound = append(bound, variable)
+	}
+	args := append([]driver.NamedValue{}, a.args...)
+	boundMap := map[string]struct{}{}
+	for _, variable := range bound {
+		if _, exists := boundMap[variable.param]; exists {
+			continue
+		}
+		boundMap[variable.param] = struct{}{}
+		args = append(args, driver.NamedValue{Name: variable.param, Value: variable.value})
+	}
+	return query, args, nil
+}
+
+// findVariableReference finds the variable referred by the name reported as unrecognized by err,
+// and returns the byte range of the name in query.
+func (a *ScriptStmtAction) findVariableReference(query string, err error) (int, int, *scriptVariable) {
+	matched := unrecognizedNamePattern.FindStringSubmatch(err.Error())
+	if len(matched) != 3 {
+		return 0, 0, nil
+	}
+	line, _ := strconv.Atoi(matched[1])
+	column, _ := strconv.Atoi(matched[2])
+	start := byteOffsetFromLineAndColumn(query, line, column)
+	if start < 0 || start >= len(query) {
+		return 0, 0, nil
+	}
+	end, name := identifierAt(query, start)
+	variable, exists := a.variables[strings.ToLower(name)]
+	if !exists {
+		return 0, 0, nil
+	}
+	return start, end, variable
+}
+
+func (a *ScriptStmtAction) nodeText(node parsed_ast.Node) string {
+	loc := node.ParseLocationRange()
+	return a.query[loc.Start().ByteOffset():loc.End().ByteOffset()]
+}
+
+// byteOffsetFromLineAndColumn converts the location reported by ZetaSQL to the byte offset in query.
+// ZetaSQL counts the column by characters and expands a tab to the next multiple of 8 columns.
+func byteOffsetFromLineAndColumn(query string, line, column int) int {
+	const tabWidth = 8
+
+	offset := 0
+	for l := 1; l < line; l++ {
+		idx := strings.IndexAny(query[offset:], "\r\n")
+		if idx < 0 {
+			return -1
+		}
+		offset += idx
+		if strings.HasPrefix(query[offset:], "\r\n") {
+			offset += 2
+		} else {
+			offset++
+		}
+	}
+	for col := 1; col < column; {
+		if offset >= len(query) {
+			return -1
+		}
+		if query[offset] == '\t' {
+			col = (col+tabWidth-1)/tabWidth*tabWidth + 1
+			offset++
+			continue
+		}
+		_, size := utf8.DecodeRuneInString(query[offset:])
+		offset += size
+		col++
+	}
+	return offset
+}
+
+// identifierAt returns the end offset and the name of the identifier starting at start.
+func identifierAt(query string, start int) (int, string) {
+	if query[start] == '`' {
+		idx := strings.IndexByte(query[start+1:], '`')
+		if idx < 0 {
+			return start, ""
+		}
+		end := start + 1 + idx
+		return end + 1, query[start+1 : end]
+	}
+	end := start
+	for end < len(query) {
+		c := query[end]
+		if c != '_' && !('a' <= c && c <= 'z') && !('A' <= c && c <= 'Z') && !('0' <= c && c <= '9') {
+			break
+		}
+		end++
+	}
+	return end, query[start:end]
+}
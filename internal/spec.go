@@ -170,9 +170,10 @@ func viewSQLiteSchema(s *TableSpec) string {
 }
 
 type ColumnSpec struct {
-	Name      string `json:"name"`
-	Type      *Type  `json:"type"`
-	IsNotNull bool   `json:"isNotNull"`
+	Name         string `json:"name"`
+	Type         *Type  `json:"type"`
+	IsNotNull    bool   `json:"isNotNull"`
+	DefaultValue string `json:"defaultValue"`
 }
 
 type Type struct {
@@ -224,7 +225,7 @@ func (t *Type) GoReflectType() (reflect.Type, error) {
 	case types.FLOAT, types.DOUBLE:
 		return reflect.TypeOf(float64(0)), nil
 	case types.BYTES, types.STRING, types.NUMERIC, types.BIG_NUMERIC,
-		types.DATE, types.DATETIME, types.TIME, types.TIMESTAMP, types.INTERVAL, types.JSON:
+		types.DATE, types.DATETIME, types.TIME, types.TIMESTAMP, types.INTERVAL, types.JSON, types.GEOGRAPHY:
 		return reflect.TypeOf(""), nil
 	case types.ARRAY:
 		elem, err := t.ElementType.GoReflectType()
@@ -265,6 +266,11 @@ func (t *Type) FormatType() string {
 	case types.STRUCT:
 		formatTypes := make([]string, 0, len(t.FieldTypes))
 		for _, field := range t.FieldTypes {
+			if field.Name == "" {
+				// anonymous field
+				formatTypes = append(formatTypes, field.Type.FormatType())
+				continue
+			}
 			formatTypes = append(formatTypes, fmt.Sprintf("`%s` %s", field.Name, field.Type.FormatType()))
 		}
 		return fmt.Sprintf("STRUCT<%s>", strings.Join(formatTypes, ","))
@@ -324,6 +330,9 @@ func (s *ColumnSpec) SQLiteSchema() string {
 	if s.IsNotNull {
 		schema += " NOT NULL"
 	}
+	if s.DefaultValue != "" {
+		schema += fmt.Sprintf(" DEFAULT (%s)", s.DefaultValue)
+	}
 	return schema
 }
 
@@ -471,7 +480,7 @@ func newTemplatedFunctionSpec(ctx context.Context, namePath *NamePath, stmt *ast
 	}, nil
 }
 
-func newColumnsFromDef(def []*ast.ColumnDefinitionNode) []*ColumnSpec {
+func newColumnsFromDef(ctx context.Context, def []*ast.ColumnDefinitionNode) ([]*ColumnSpec, error) {
 	columns := []*ColumnSpec{}
 	for _, columnNode := range def {
 		annotation := columnNode.Annotations()
@@ -484,13 +493,23 @@ func newColumnsFromDef(def []*ast.ColumnDefinitionNode) []*ColumnSpec {
 			}
 			isNotNull = annotation.NotNull()
 		}
+		var defaultValue string
+		if def := columnNode.DefaultValue(); def != nil {
+			// the default value is evaluated for each insertion, so CURRENT_* functions must not be fixed.
+			value, err := newNode(def.Expression()).FormatSQL(withoutCurrentTime(ctx))
+			if err != nil {
+				return nil, fmt.Errorf("failed to format default value of %s: %w", columnNode.Name(), err)
+			}
+			defaultValue = value
+		}
 		columns = append(columns, &ColumnSpec{
-			Name:      columnNode.Name(),
-			Type:      newType(columnNode.Type()),
-			IsNotNull: isNotNull,
+			Name:         columnNode.Name(),
+			Type:         newType(columnNode.Type()),
+			IsNotNull:    isNotNull,
+			DefaultValue: defaultValue,
 		})
 	}
-	return columns
+	return columns, nil
 }
 
 func newColumnsFromOutputColumns(def []*ast.OutputColumnNode) []*ColumnSpec {
@@ -513,17 +532,21 @@ func newPrimaryKey(key *ast.PrimaryKeyNode) []string {
 	return key.ColumnNameList()
 }
 
-func newTableSpec(namePath *NamePath, stmt *ast.CreateTableStmtNode) *TableSpec {
+func newTableSpec(ctx context.Context, namePath *NamePath, stmt *ast.CreateTableStmtNode) (*TableSpec, error) {
+	columns, err := newColumnsFromDef(ctx, stmt.ColumnDefinitionList())
+	if err != nil {
+		return nil, err
+	}
 	now := time.Now()
 	return &TableSpec{
 		IsTemp:     stmt.CreateScope() == ast.CreateScopeTemp,
 		NamePath:   namePath.mergePath(stmt.NamePath()),
-		Columns:    newColumnsFromDef(stmt.ColumnDefinitionList()),
+		Columns:    columns,
 		PrimaryKey: newPrimaryKey(stmt.PrimaryKey()),
 		CreateMode: stmt.CreateMode(),
 		UpdatedAt:  now,
 		CreatedAt:  now,
-	}
+	}, nil
 }
 
 func newTableAsViewSpec(namePath *NamePath, query string, stmt *ast.CreateViewStmtNode) *TableSpec {
@@ -550,7 +573,7 @@ func newTableAsViewSpec(namePath *NamePath, query string, stmt *ast.CreateViewSt
 	}
 }
 
-func newTableAsSelectSpec(namePath *NamePath, query string, stmt *ast.CreateTableAsSelectStmtNode) *TableSpec {
+func newTableAsSelectSpec(ctx context.Context, namePath *NamePath, query string, stmt *ast.CreateTableAsSelectStmtNode) (*TableSpec, error) {
 	var outputColumns []string
 	for _, column := range stmt.OutputColumnList() {
 		colName := column.Name()
@@ -561,17 +584,21 @@ func newTableAsSelectSpec(namePath *NamePath, query string, stmt *ast.CreateTabl
 			fmt.Sprintf("`%s#%d` AS `%s`", refColumnName, colID, colName),
 		)
 	}
+	columns, err := newColumnsFromDef(ctx, stmt.ColumnDefinitionList())
+	if err != nil {
+		return nil, err
+	}
 	now := time.Now()
 	return &TableSpec{
 		IsTemp:     stmt.CreateScope() == ast.CreateScopeTemp,
 		NamePath:   namePath.mergePath(stmt.NamePath()),
-		Columns:    newColumnsFromDef(stmt.ColumnDefinitionList()),
+		Columns:    columns,
 		PrimaryKey: newPrimaryKey(stmt.PrimaryKey()),
 		CreateMode: stmt.CreateMode(),
 		Query:      fmt.Sprintf("SELECT %s FROM (%s)", strings.Join(outputColumns, ","), query),
 		UpdatedAt:  now,
 		CreatedAt:  now,
-	}
+	}, nil
 }
 
 func newType(t types.Type) *Type {
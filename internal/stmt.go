@@ -185,18 +185,20 @@ func (s *DMLStmt) QueryContext(ctx context.Context, query string, args []driver.
 }
 
 type QueryStmt struct {
-	stmt           *sql.Stmt
-	args           []*ast.ParameterNode
-	formattedQuery string
-	outputColumns  []*ColumnSpec
+	stmt             *sql.Stmt
+	args             []*ast.ParameterNode
+	formattedQuery   string
+	outputColumns    []*ColumnSpec
+	isRatNumericMode bool
 }
 
-func newQueryStmt(stmt *sql.Stmt, args []*ast.ParameterNode, formattedQuery string, outputColumns []*ColumnSpec) *QueryStmt {
+func newQueryStmt(stmt *sql.Stmt, args []*ast.ParameterNode, formattedQuery string, outputColumns []*ColumnSpec, isRatNumericMode bool) *QueryStmt {
 	return &QueryStmt{
-		stmt:           stmt,
-		args:           args,
-		formattedQuery: formattedQuery,
-		outputColumns:  outputColumns,
+		stmt:             stmt,
+		args:             args,
+		formattedQuery:   formattedQuery,
+		outputColumns:    outputColumns,
+		isRatNumericMode: isRatNumericMode,
 	}
 }
 
@@ -250,7 +252,7 @@ func (s *QueryStmt) Query(args []driver.Value) (driver.Rows, error) {
 			err,
 		)
 	}
-	return &Rows{rows: rows, columns: s.outputColumns}, nil
+	return &Rows{rows: rows, columns: s.outputColumns, isRatNumericMode: s.isRatNumericMode}, nil
 }
 
 func (s *QueryStmt) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
@@ -5,8 +5,11 @@ import (
 	"database/sql/driver"
 	"fmt"
 	"strings"
+	"time"
 
+	parsed_ast "github.com/goccy/go-zetasql/ast"
 	ast "github.com/goccy/go-zetasql/resolved_ast"
+	"github.com/goccy/go-zetasql/types"
 )
 
 type StmtAction interface {
@@ -46,21 +49,37 @@ func (a *CreateTableStmtAction) createIndexAutomatically(ctx context.Context, co
 		if !col.Type.AvailableAutoIndex() {
 			continue
 		}
-		indexName := fmt.Sprintf("zetasqlite_autoindex_%s_%s", col.Name, strings.Join(a.spec.NamePath, "_"))
-		createIndexQuery := fmt.Sprintf(
-			"CREATE INDEX IF NOT EXISTS %s ON `%s`(`%s`)",
-			indexName,
-			a.spec.TableName(),
-			col.Name,
-		)
-		if _, err := conn.ExecContext(ctx, createIndexQuery); err != nil {
-			return fmt.Errorf("failed to create index automatically %s: %w", createIndexQuery, err)
+		if err := createIndexAutomatically(ctx, conn, a.spec, col); err != nil {
+			return err
 		}
 	}
 	return nil
 }
 
+func autoIndexName(spec *TableSpec, column string) string {
+	return fmt.Sprintf("zetasqlite_autoindex_%s_%s", column, strings.Join(spec.NamePath, "_"))
+}
+
+func createIndexAutomatically(ctx context.Context, conn *Conn, spec *TableSpec, col *ColumnSpec) error {
+	createIndexQuery := fmt.Sprintf(
+		"CREATE INDEX IF NOT EXISTS %s ON `%s`(`%s`)",
+		autoIndexName(spec, col.Name),
+		spec.TableName(),
+		col.Name,
+	)
+	if _, err := conn.ExecContext(ctx, createIndexQuery); err != nil {
+		return fmt.Errorf("failed to create index automatically %s: %w", createIndexQuery, err)
+	}
+	return nil
+}
+
 func (a *CreateTableStmtAction) exec(ctx context.Context, conn *Conn) error {
+	if a.spec.CreateMode == ast.CreateIfNotExistsMode {
+		if _, exists := a.catalog.lookupTableSpec(a.spec.TableName()); exists {
+			// keep the existing table and its schema as it is.
+			return nil
+		}
+	}
 	if a.spec.CreateMode == ast.CreateOrReplaceMode {
 		if _, err := conn.ExecContext(
 			ctx,
@@ -249,6 +268,7 @@ func (a *CreateFunctionStmtAction) Cleanup(ctx context.Context, conn *Conn) erro
 type DropStmtAction struct {
 	name           string
 	objectType     string
+	isIfExists     bool
 	funcMap        map[string]*FunctionSpec
 	catalog        *Catalog
 	query          string
@@ -259,15 +279,30 @@ type DropStmtAction struct {
 func (a *DropStmtAction) exec(ctx context.Context, conn *Conn) error {
 	switch a.objectType {
 	case "TABLE", "VIEW":
+		spec, exists := a.catalog.lookupTableSpec(a.name)
+		if !exists {
+			if a.isIfExists {
+				return nil
+			}
+			return fmt.Errorf("failed to drop %s: %s is not found", strings.ToLower(a.objectType), a.name)
+		}
+		if spec.IsView != (a.objectType == "VIEW") {
+			return fmt.Errorf("failed to drop %s: %s is not a %s", strings.ToLower(a.objectType), a.name, strings.ToLower(a.objectType))
+		}
 		if _, err := conn.ExecContext(ctx, a.formattedQuery, a.args...); err != nil {
 			return fmt.Errorf("failed to exec %s: %w", a.query, err)
 		}
-		spec := a.catalog.tableMap[a.name]
 		if err := a.catalog.DeleteTableSpec(ctx, conn, a.name); err != nil {
 			return fmt.Errorf("failed to delete table spec: %w", err)
 		}
 		conn.deleteTable(spec)
 	case "FUNCTION":
+		if !a.catalog.existsFunctionSpec(a.name) {
+			if a.isIfExists {
+				return nil
+			}
+			return fmt.Errorf("failed to drop function: %s is not found", a.name)
+		}
 		if err := a.catalog.DeleteFunctionSpec(ctx, conn, a.name); err != nil {
 			return fmt.Errorf("failed to delete function spec: %w", err)
 		}
@@ -305,6 +340,196 @@ func (a *DropStmtAction) Cleanup(ctx context.Context, conn *Conn) error {
 	return nil
 }
 
+// alterColumnAction represents one of ADD COLUMN, DROP COLUMN and RENAME COLUMN actions of ALTER TABLE statement.
+type alterColumnAction struct {
+	kind    ast.Kind
+	column  *ColumnSpec
+	name    string
+	newName string
+	// isIfExists is IF NOT EXISTS for ADD COLUMN, and IF EXISTS for the others.
+	isIfExists bool
+}
+
+type AlterTableStmtAction struct {
+	query           string
+	name            string
+	isIfExists      bool
+	actions         []*alterColumnAction
+	catalog         *Catalog
+	isAutoIndexMode bool
+}
+
+func (a *AlterTableStmtAction) exec(ctx context.Context, conn *Conn) error {
+	spec, exists := a.catalog.lookupTableSpec(a.name)
+	if !exists {
+		if a.isIfExists {
+			return nil
+		}
+		return fmt.Errorf("failed to alter table: %s is not found", a.name)
+	}
+	if spec.IsView {
+		return fmt.Errorf("failed to alter table: %s is not a table", a.name)
+	}
+	newSpec := *spec
+	newSpec.Columns = append([]*ColumnSpec{}, spec.Columns...)
+	newSpec.PrimaryKey = append([]string{}, spec.PrimaryKey...)
+
+	// apply all actions or none of them so that the table keeps matching its spec in the catalog.
+	if _, err := conn.ExecContext(ctx, "SAVEPOINT zetasqlite_alter_table"); err != nil {
+		return fmt.Errorf("failed to begin altering table: %w", err)
+	}
+	if err := a.execActions(ctx, conn, &newSpec); err != nil {
+		for _, query := range []string{"ROLLBACK TO zetasqlite_alter_table", "RELEASE zetasqlite_alter_table"} {
+			if _, rollbackErr := conn.ExecContext(ctx, query); rollbackErr != nil {
+				return fmt.Errorf("failed to rollback altering table: %v: %w", rollbackErr, err)
+			}
+		}
+		return err
+	}
+	if _, err := conn.ExecContext(ctx, "RELEASE zetasqlite_alter_table"); err != nil {
+		return fmt.Errorf("failed to commit altering table: %w", err)
+	}
+	if !newSpec.IsTemp {
+		conn.updateTable(&newSpec)
+	}
+	return nil
+}
+
+func (a *AlterTableStmtAction) execActions(ctx context.Context, conn *Conn, spec *TableSpec) error {
+	for _, action := range a.actions {
+		if err := a.execAction(ctx, conn, spec, action); err != nil {
+			return err
+		}
+	}
+	spec.UpdatedAt = time.Now()
+	if err := a.catalog.UpdateTableSpec(ctx, conn, spec); err != nil {
+		return fmt.Errorf("failed to update table spec: %w", err)
+	}
+	return nil
+}
+
+func (a *AlterTableStmtAction) execAction(ctx context.Context, conn *Conn, spec *TableSpec, action *alterColumnAction) error {
+	switch action.kind {
+	case ast.AddColumnAction:
+		if spec.Column(action.column.Name) != nil {
+			if action.isIfExists {
+				return nil
+			}
+			return fmt.Errorf("failed to add column: %s already exists in %s", action.column.Name, a.name)
+		}
+		if _, err := conn.ExecContext(
+			ctx,
+			fmt.Sprintf("ALTER TABLE `%s` ADD COLUMN %s", a.name, action.column.SQLiteSchema()),
+		); err != nil {
+			return fmt.Errorf("failed to exec %s: %w", a.query, err)
+		}
+		if a.isAutoIndexMode && action.column.Type.AvailableAutoIndex() {
+			if err := createIndexAutomatically(ctx, conn, spec, action.column); err != nil {
+				return err
+			}
+		}
+		spec.Columns = append(spec.Columns, action.column)
+	case ast.DropColumnAction:
+		if spec.Column(action.name) == nil {
+			if action.isIfExists {
+				return nil
+			}
+			return fmt.Errorf("failed to drop column: %s is not found in %s", action.name, a.name)
+		}
+		// SQLite cannot drop the indexed column.
+		if _, err := conn.ExecContext(
+			ctx,
+			fmt.Sprintf("DROP INDEX IF EXISTS %s", autoIndexName(spec, action.name)),
+		); err != nil {
+			return fmt.Errorf("failed to drop index of %s: %w", action.name, err)
+		}
+		if _, err := conn.ExecContext(
+			ctx,
+			fmt.Sprintf("ALTER TABLE `%s` DROP COLUMN `%s`", a.name, action.name),
+		); err != nil {
+			return fmt.Errorf("failed to exec %s: %w", a.query, err)
+		}
+		columns := make([]*ColumnSpec, 0, len(spec.Columns))
+		for _, col := range spec.Columns {
+			if col.Name == action.name {
+				continue
+			}
+			columns = append(columns, col)
+		}
+		spec.Columns = columns
+	case ast.RenameColumnAction:
+		col := spec.Column(action.name)
+		if col == nil {
+			if action.isIfExists {
+				return nil
+			}
+			return fmt.Errorf("failed to rename column: %s is not found in %s", action.name, a.name)
+		}
+		if spec.Column(action.newName) != nil {
+			return fmt.Errorf("failed to rename column: %s already exists in %s", action.newName, a.name)
+		}
+		if _, err := conn.ExecContext(
+			ctx,
+			fmt.Sprintf("ALTER TABLE `%s` RENAME COLUMN `%s` TO `%s`", a.name, action.name, action.newName),
+		); err != nil {
+			return fmt.Errorf("failed to exec %s: %w", a.query, err)
+		}
+		renamed := *col
+		renamed.Name = action.newName
+		// the automatically created index is named after the column, so rename it with the column.
+		if _, err := conn.ExecContext(
+			ctx,
+			fmt.Sprintf("DROP INDEX IF EXISTS %s", autoIndexName(spec, action.name)),
+		); err != nil {
+			return fmt.Errorf("failed to drop index of %s: %w", action.name, err)
+		}
+		if a.isAutoIndexMode && renamed.Type.AvailableAutoIndex() {
+			if err := createIndexAutomatically(ctx, conn, spec, &renamed); err != nil {
+				return err
+			}
+		}
+		for idx, c := range spec.Columns {
+			if c == col {
+				spec.Columns[idx] = &renamed
+			}
+		}
+		for idx, key := range spec.PrimaryKey {
+			if key == action.name {
+				spec.PrimaryKey[idx] = action.newName
+			}
+		}
+	default:
+		return fmt.Errorf("unexpected ALTER TABLE action kind %d", action.kind)
+	}
+	return nil
+}
+
+func (a *AlterTableStmtAction) Prepare(ctx context.Context, conn *Conn) (driver.Stmt, error) {
+	return nil, fmt.Errorf("currently unsupported to prepare ALTER TABLE statement")
+}
+
+func (a *AlterTableStmtAction) ExecContext(ctx context.Context, conn *Conn) (driver.Result, error) {
+	if err := a.exec(ctx, conn); err != nil {
+		return nil, err
+	}
+	return &Result{conn: conn}, nil
+}
+
+func (a *AlterTableStmtAction) QueryContext(ctx context.Context, conn *Conn) (*Rows, error) {
+	if err := a.exec(ctx, conn); err != nil {
+		return nil, err
+	}
+	return &Rows{conn: conn}, nil
+}
+
+func (a *AlterTableStmtAction) Args() []interface{} {
+	return nil
+}
+
+func (a *AlterTableStmtAction) Cleanup(ctx context.Context, conn *Conn) error {
+	return nil
+}
+
 type DMLStmtAction struct {
 	query          string
 	params         []*ast.ParameterNode
@@ -352,12 +577,13 @@ func (a *DMLStmtAction) Cleanup(ctx context.Context, conn *Conn) error {
 }
 
 type QueryStmtAction struct {
-	query          string
-	params         []*ast.ParameterNode
-	args           []interface{}
-	formattedQuery string
-	outputColumns  []*ColumnSpec
-	isExplainMode  bool
+	query            string
+	params           []*ast.ParameterNode
+	args             []interface{}
+	formattedQuery   string
+	outputColumns    []*ColumnSpec
+	isExplainMode    bool
+	isRatNumericMode bool
 }
 
 func (a *QueryStmtAction) Prepare(ctx context.Context, conn *Conn) (driver.Stmt, error) {
@@ -365,7 +591,7 @@ func (a *QueryStmtAction) Prepare(ctx context.Context, conn *Conn) (driver.Stmt,
 	if err != nil {
 		return nil, fmt.Errorf("failed to prepare %s: %w", a.query, err)
 	}
-	return newQueryStmt(s, a.params, a.formattedQuery, a.outputColumns), nil
+	return newQueryStmt(s, a.params, a.formattedQuery, a.outputColumns, a.isRatNumericMode), nil
 }
 
 func (a *QueryStmtAction) ExecContext(ctx context.Context, conn *Conn) (driver.Result, error) {
@@ -413,7 +639,13 @@ func (a *QueryStmtAction) QueryContext(ctx context.Context, conn *Conn) (*Rows,
 	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("failed to query %s: %w", a.query, err)
 	}
-	return &Rows{conn: conn, rows: rows, columns: a.outputColumns}, nil
+	return &Rows{
+		ctx:              ctx,
+		conn:             conn,
+		rows:             rows,
+		columns:          a.outputColumns,
+		isRatNumericMode: a.isRatNumericMode,
+	}, nil
 }
 
 func (a *QueryStmtAction) Args() []interface{} {
@@ -543,3 +775,116 @@ func (a *MergeStmtAction) Args() []interface{} {
 func (a *MergeStmtAction) Cleanup(ctx context.Context, conn *Conn) error {
 	return nil
 }
+
+// scriptStmtPosition is the position of a statement within a multi-statement script.
+type scriptStmtPosition struct {
+	index  int
+	line   int
+	column int
+}
+
+func newScriptStmtPosition(query string, idx int, stmt parsed_ast.StatementNode) *scriptStmtPosition {
+	pos := &scriptStmtPosition{index: idx + 1, line: 1, column: 1}
+	loc := stmt.ParseLocationRange()
+	if loc == nil {
+		return pos
+	}
+	offset := loc.Start().ByteOffset()
+	if offset < 0 || offset > len(query) {
+		return pos
+	}
+	prefix := query[:offset]
+	pos.line = strings.Count(prefix, "\n") + 1
+	pos.column = offset - strings.LastIndex(prefix, "\n")
+	return pos
+}
+
+func (p *scriptStmtPosition) wrapError(err error) error {
+	if p == nil || err == nil {
+		return err
+	}
+	return fmt.Errorf("failed to run statement %d [at %d:%d]: %w", p.index, p.line, p.column, err)
+}
+
+// scriptStmtAction reports the position of the statement when an action of a multi-statement script fails.
+type scriptStmtAction struct {
+	StmtAction
+	pos *scriptStmtPosition
+}
+
+func (a *scriptStmtAction) Prepare(ctx context.Context, conn *Conn) (driver.Stmt, error) {
+	stmt, err := a.StmtAction.Prepare(ctx, conn)
+	if err != nil {
+		return nil, a.pos.wrapError(err)
+	}
+	return stmt, nil
+}
+
+func (a *scriptStmtAction) ExecContext(ctx context.Context, conn *Conn) (driver.Result, error) {
+	result, err := a.StmtAction.ExecContext(ctx, conn)
+	if err != nil {
+		return nil, a.pos.wrapError(err)
+	}
+	return result, nil
+}
+
+func (a *scriptStmtAction) QueryContext(ctx context.Context, conn *Conn) (*Rows, error) {
+	rows, err := a.StmtAction.QueryContext(ctx, conn)
+	if err != nil {
+		return nil, a.pos.wrapError(err)
+	}
+	return rows, nil
+}
+
+// ExplainStmtAction returns the SQLite queries generated from the explained statement
+// and the parameters bound to them in order without executing them.
+type ExplainStmtAction struct {
+	queries []string
+	params  []*ast.ParameterNode
+}
+
+var explainOutputColumns = []*ColumnSpec{
+	{Name: "query", Type: &Type{Name: "STRING", Kind: int(types.STRING)}},
+	{Name: "params", Type: &Type{Name: "ARRAY<STRING>", Kind: int(types.ARRAY), ElementType: &Type{Name: "STRING", Kind: int(types.STRING)}}},
+}
+
+func (a *ExplainStmtAction) Prepare(ctx context.Context, conn *Conn) (driver.Stmt, error) {
+	return nil, fmt.Errorf("unsupported prepare for EXPLAIN statement")
+}
+
+func (a *ExplainStmtAction) ExecContext(ctx context.Context, conn *Conn) (driver.Result, error) {
+	return &Result{conn: conn}, nil
+}
+
+func (a *ExplainStmtAction) QueryContext(ctx context.Context, conn *Conn) (*Rows, error) {
+	params := &ArrayValue{}
+	for _, param := range a.params {
+		if param.Name() == "" {
+			params.values = append(params.values, StringValue("?"))
+		} else {
+			params.values = append(params.values, StringValue(fmt.Sprintf("@%s", param.Name())))
+		}
+	}
+	query, err := EncodeValue(StringValue(strings.Join(a.queries, ";\n")))
+	if err != nil {
+		return nil, err
+	}
+	encodedParams, err := EncodeValue(params)
+	if err != nil {
+		return nil, err
+	}
+	// select the encoded values by SQLite to return them in the same way as the other queries.
+	rows, err := conn.QueryContext(ctx, "SELECT ?, ?", query, encodedParams)
+	if err != nil {
+		return nil, fmt.Errorf("failed to explain: %w", err)
+	}
+	return &Rows{ctx: ctx, conn: conn, rows: rows, columns: explainOutputColumns}, nil
+}
+
+func (a *ExplainStmtAction) Args() []interface{} {
+	return nil
+}
+
+func (a *ExplainStmtAction) Cleanup(ctx context.Context, conn *Conn) error {
+	return nil
+}
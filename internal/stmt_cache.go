@@ -0,0 +1,91 @@
+package internal
+
+import (
+	"container/list"
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"sync"
+
+	ast "github.com/goccy/go-zetasql/resolved_ast"
+)
+
+const defaultStmtCacheSize = 1024
+
+// stmtCacheEntry keeps the result of analyzing and formatting a query or DML statement.
+// It doesn't depend on the argument values, so the action is rebuilt from it by binding the arguments.
+type stmtCacheEntry struct {
+	key            string
+	catalogVersion uint64
+	isQuery        bool
+	formattedQuery string
+	params         []*ast.ParameterNode
+	outputColumns  []*ColumnSpec
+}
+
+// stmtCache is the LRU cache of the analyzed statements shared by the connections using the same catalog.
+type stmtCache struct {
+	mu      sync.Mutex
+	size    int
+	entries *list.List
+	keyMap  map[string]*list.Element
+}
+
+func newStmtCache(size int) *stmtCache {
+	return &stmtCache{
+		size:    size,
+		entries: list.New(),
+		keyMap:  map[string]*list.Element{},
+	}
+}
+
+// newStmtCacheKey returns the key of the cache for query.
+// The cache is shared by the connections, so the key includes the name path settings of the connection.
+func newStmtCacheKey(namePath *NamePath, query string, args []driver.NamedValue) string {
+	var b strings.Builder
+	b.WriteString(strings.Join(namePath.path, "."))
+	fmt.Fprintf(&b, ":%d", namePath.maxNum)
+	b.WriteByte(0)
+	for _, arg := range args {
+		fmt.Fprintf(&b, "%s:%T,", arg.Name, arg.Value)
+	}
+	b.WriteByte(0)
+	b.WriteString(query)
+	return b.String()
+}
+
+// get returns the entry for key if it was analyzed with the current catalog.
+func (c *stmtCache) get(key string, catalogVersion uint64) *stmtCacheEntry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, exists := c.keyMap[key]
+	if !exists {
+		return nil
+	}
+	entry := elem.Value.(*stmtCacheEntry)
+	if entry.catalogVersion != catalogVersion {
+		c.entries.Remove(elem)
+		delete(c.keyMap, key)
+		return nil
+	}
+	c.entries.MoveToFront(elem)
+	return entry
+}
+
+func (c *stmtCache) add(entry *stmtCacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, exists := c.keyMap[entry.key]; exists {
+		elem.Value = entry
+		c.entries.MoveToFront(elem)
+		return
+	}
+	c.keyMap[entry.key] = c.entries.PushFront(entry)
+	for c.entries.Len() > c.size {
+		oldest := c.entries.Back()
+		c.entries.Remove(oldest)
+		delete(c.keyMap, oldest.Value.(*stmtCacheEntry).key)
+	}
+}
@@ -0,0 +1,23 @@
+package internal
+
+import (
+	"testing"
+)
+
+func TestStmtCacheKey(t *testing.T) {
+	newNamePath := func(t *testing.T, maxNum int) *NamePath {
+		namePath := new(NamePath)
+		if err := namePath.setPath([]string{"project1", "dataset1"}); err != nil {
+			t.Fatal(err)
+		}
+		namePath.setMaxNum(maxNum)
+		return namePath
+	}
+	const query = "SELECT * FROM table1"
+	if newStmtCacheKey(newNamePath(t, 3), query, nil) != newStmtCacheKey(newNamePath(t, 3), query, nil) {
+		t.Fatal("expected the same key for the same name path")
+	}
+	if newStmtCacheKey(newNamePath(t, 3), query, nil) == newStmtCacheKey(newNamePath(t, 2), query, nil) {
+		t.Fatal("expected different keys for different max name path")
+	}
+}
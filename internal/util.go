@@ -79,6 +79,8 @@ func modifyTimeZone(t time.Time, loc *time.Location) (time.Time, error) {
 	return parseTimestamp(format, loc)
 }
 
+// timeFromUnixNano returns the time in UTC so that the current time passed by WithCurrentTime
+// is interpreted the same as the default time zone of CURRENT_* functions.
 func timeFromUnixNano(unixNano int64) time.Time {
-	return time.Unix(0, unixNano)
+	return time.Unix(0, unixNano).UTC()
 }
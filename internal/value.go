@@ -475,21 +475,43 @@ func (bv BytesValue) Format(verb rune) string {
 		}
 		return ret
 	case 'T':
-		ret := `b"`
-		for _, b := range bv {
-			if printableChar(b) {
-				ret += fmt.Sprintf("%c", b)
-			} else {
-				ret += fmt.Sprintf("\\x%02x", b)
-			}
-		}
-		ret += `"`
-		return ret
+		return bv.toLiteral()
 	}
 	v, _ := bv.ToString()
 	return v
 }
 
+// toLiteral returns the BYTES literal representation in the same way as ZetaSQL.
+// The value is quoted by double quotes unless it contains double quotes and no single quotes.
+func (bv BytesValue) toLiteral() string {
+	quote := byte('"')
+	if bytes.IndexByte(bv, '"') >= 0 && bytes.IndexByte(bv, '\'') < 0 {
+		quote = '\''
+	}
+	var buf strings.Builder
+	buf.WriteByte('b')
+	buf.WriteByte(quote)
+	for _, b := range bv {
+		switch {
+		case b == '\n':
+			buf.WriteString(`\n`)
+		case b == '\r':
+			buf.WriteString(`\r`)
+		case b == '\t':
+			buf.WriteString(`\t`)
+		case b == '\\' || b == quote:
+			buf.WriteByte('\\')
+			buf.WriteByte(b)
+		case printableChar(b):
+			buf.WriteByte(b)
+		default:
+			fmt.Fprintf(&buf, "\\x%02x", b)
+		}
+	}
+	buf.WriteByte(quote)
+	return buf.String()
+}
+
 func (bv BytesValue) Interface() interface{} {
 	return []byte(bv)
 }
@@ -620,6 +642,24 @@ func (fv FloatValue) ToRat() (*big.Rat, error) {
 }
 
 func (fv FloatValue) Format(verb rune) string {
+	f64 := float64(fv)
+	switch verb {
+	case 't', 'T':
+		if math.IsInf(f64, 0) || math.IsNaN(f64) {
+			s := strings.ToLower(fmt.Sprint(f64))
+			s = strings.TrimPrefix(s, "+")
+			if verb == 'T' {
+				return fmt.Sprintf(`CAST(%q AS FLOAT64)`, s)
+			}
+			return s
+		}
+		// FLOAT64 value always has a decimal point or an exponent ( e.g. 1.0, 1e+20 ).
+		s := strconv.FormatFloat(f64, 'g', -1, 64)
+		if !strings.ContainsAny(s, ".e") {
+			s += ".0"
+		}
+		return s
+	}
 	return fmt.Sprint(fv)
 }
 
@@ -788,6 +828,12 @@ func (nv *NumericValue) ToRat() (*big.Rat, error) {
 }
 
 func (nv *NumericValue) Format(verb rune) string {
+	if verb == 'T' {
+		if nv.isBigNumeric {
+			return fmt.Sprintf(`BIGNUMERIC %q`, nv.toString())
+		}
+		return fmt.Sprintf(`NUMERIC %q`, nv.toString())
+	}
 	return nv.toString()
 }
 
@@ -982,6 +1028,9 @@ func (jv JsonValue) ToRat() (*big.Rat, error) {
 }
 
 func (jv JsonValue) Format(verb rune) string {
+	if verb == 'T' {
+		return fmt.Sprintf(`JSON '%s'`, strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(string(jv)))
+	}
 	return string(jv)
 }
 
@@ -1361,9 +1410,17 @@ func (sv *StructValue) ToInt64() (int64, error) {
 }
 
 func (sv *StructValue) ToString() (string, error) {
+	return sv.toJSON(false)
+}
+
+func (sv *StructValue) toJSON(nameAnonymousField bool) (string, error) {
 	fields := []string{}
 	for i := 0; i < len(sv.keys); i++ {
 		key := sv.keys[i]
+		if key == "" && nameAnonymousField {
+			// anonymous field is named by its position in the same way as BigQuery.
+			key = fmt.Sprintf("_field_%d", i+1)
+		}
 		value := sv.values[i]
 		if value == nil {
 			fields = append(
@@ -1409,7 +1466,7 @@ func (sv *StructValue) ToStruct() (*StructValue, error) {
 }
 
 func (sv *StructValue) ToJSON() (string, error) {
-	return sv.ToString()
+	return sv.toJSON(true)
 }
 
 func (sv *StructValue) ToTime() (time.Time, error) {
@@ -1453,32 +1510,14 @@ func (sv *StructValue) Interface() interface{} {
 type DateValue time.Time
 
 func (d DateValue) AddDateWithInterval(v int, interval string) (Value, error) {
-	switch interval {
-	case "WEEK":
-		return DateValue(time.Time(d).AddDate(0, 0, v*7)), nil
-	case "MONTH":
-		return DateValue(time.Time(d).AddDate(0, v, 0)), nil
-	case "YEAR":
-		return DateValue(time.Time(d).AddDate(v, 0, 0)), nil
-	default:
-		return DateValue(time.Time(d).AddDate(0, 0, v)), nil
-	}
+	return DATE_ADD(time.Time(d), int64(v), interval)
 }
 
 func (d DateValue) Add(v Value) (Value, error) {
 	src := time.Time(d)
 	switch vv := v.(type) {
 	case *IntervalValue:
-		return DatetimeValue(time.Date(
-			src.Year()+int(vv.Years),
-			time.Month(int(src.Month())+int(vv.Months)),
-			src.Day()+int(vv.Days),
-			src.Hour()+int(vv.Hours),
-			src.Minute()+int(vv.Minutes),
-			src.Second()+int(vv.Seconds),
-			src.Nanosecond()+int(vv.SubSecondNanos),
-			src.Location(),
-		)), nil
+		return DatetimeValue(addInterval(src, vv, 1)), nil
 	case IntValue:
 		return DateValue(time.Time(d).AddDate(0, 0, int(vv))), nil
 	}
@@ -1489,16 +1528,7 @@ func (d DateValue) Sub(v Value) (Value, error) {
 	src := time.Time(d)
 	switch vv := v.(type) {
 	case *IntervalValue:
-		return DatetimeValue(time.Date(
-			src.Year()-int(vv.Years),
-			time.Month(int(src.Month())-int(vv.Months)),
-			src.Day()-int(vv.Days),
-			src.Hour()-int(vv.Hours),
-			src.Minute()-int(vv.Minutes),
-			src.Second()-int(vv.Seconds),
-			src.Nanosecond()-int(vv.SubSecondNanos),
-			src.Location(),
-		)), nil
+		return DatetimeValue(addInterval(src, vv, -1)), nil
 	case IntValue:
 		return DateValue(time.Time(d).AddDate(0, 0, -int(vv))), nil
 	}
@@ -1631,16 +1661,7 @@ type DatetimeValue time.Time
 func (d DatetimeValue) Add(v Value) (Value, error) {
 	src := time.Time(d)
 	if vv, ok := v.(*IntervalValue); ok {
-		return DatetimeValue(time.Date(
-			src.Year()+int(vv.Years),
-			time.Month(int(src.Month())+int(vv.Months)),
-			src.Day()+int(vv.Days),
-			src.Hour()+int(vv.Hours),
-			src.Minute()+int(vv.Minutes),
-			src.Second()+int(vv.Seconds),
-			src.Nanosecond()+int(vv.SubSecondNanos),
-			src.Location(),
-		)), nil
+		return DatetimeValue(addInterval(src, vv, 1)), nil
 	}
 	return nil, fmt.Errorf("failed to use add operator for datetime and %T type", v)
 }
@@ -1648,16 +1669,7 @@ func (d DatetimeValue) Add(v Value) (Value, error) {
 func (d DatetimeValue) Sub(v Value) (Value, error) {
 	src := time.Time(d)
 	if vv, ok := v.(*IntervalValue); ok {
-		return DatetimeValue(time.Date(
-			src.Year()-int(vv.Years),
-			time.Month(int(src.Month())-int(vv.Months)),
-			src.Day()-int(vv.Days),
-			src.Hour()-int(vv.Hours),
-			src.Minute()-int(vv.Minutes),
-			src.Second()-int(vv.Seconds),
-			src.Nanosecond()-int(vv.SubSecondNanos),
-			src.Location(),
-		)), nil
+		return DatetimeValue(addInterval(src, vv, -1)), nil
 	}
 	dst, err := v.ToTime()
 	if err != nil {
@@ -1760,14 +1772,13 @@ func (d DatetimeValue) ToRat() (*big.Rat, error) {
 }
 
 func (d DatetimeValue) Format(verb rune) string {
-	formatted := time.Time(d).Format(datetimeFormat)
 	switch verb {
 	case 't':
-		return formatted
+		return time.Time(d).Format("2006-01-02 15:04:05.999999")
 	case 'T':
-		return fmt.Sprintf(`DATETIME %q`, formatted)
+		return fmt.Sprintf(`DATETIME %q`, time.Time(d).Format("2006-01-02 15:04:05.999999"))
 	}
-	return formatted
+	return time.Time(d).Format(datetimeFormat)
 }
 
 func (d DatetimeValue) Interface() interface{} {
@@ -1915,16 +1926,7 @@ func (t TimestampValue) AddValueWithPart(v int64, part string) (Value, error) {
 func (t TimestampValue) Add(v Value) (Value, error) {
 	src := time.Time(t)
 	if vv, ok := v.(*IntervalValue); ok {
-		return TimestampValue(time.Date(
-			src.Year()+int(vv.Years),
-			time.Month(int(src.Month())+int(vv.Months)),
-			src.Day()+int(vv.Days),
-			src.Hour()+int(vv.Hours),
-			src.Minute()+int(vv.Minutes),
-			src.Second()+int(vv.Seconds),
-			src.Nanosecond()+int(vv.SubSecondNanos),
-			src.Location(),
-		)), nil
+		return TimestampValue(addInterval(src, vv, 1)), nil
 	}
 	return nil, fmt.Errorf("failed to use add operator for timestamp and %T type", v)
 }
@@ -1932,16 +1934,7 @@ func (t TimestampValue) Add(v Value) (Value, error) {
 func (t TimestampValue) Sub(v Value) (Value, error) {
 	src := time.Time(t)
 	if vv, ok := v.(*IntervalValue); ok {
-		return TimestampValue(time.Date(
-			src.Year()-int(vv.Years),
-			time.Month(int(src.Month())-int(vv.Months)),
-			src.Day()-int(vv.Days),
-			src.Hour()-int(vv.Hours),
-			src.Minute()-int(vv.Minutes),
-			src.Second()-int(vv.Seconds),
-			src.Nanosecond()-int(vv.SubSecondNanos),
-			src.Location(),
-		)), nil
+		return TimestampValue(addInterval(src, vv, -1)), nil
 	}
 	dst, err := v.ToTime()
 	if err != nil {
@@ -2044,7 +2037,7 @@ func (t TimestampValue) ToRat() (*big.Rat, error) {
 }
 
 func (t TimestampValue) Format(verb rune) string {
-	const timestampPrintableFormat = "2006-01-02 15:04:05"
+	const timestampPrintableFormat = "2006-01-02 15:04:05.999999"
 	formatted := time.Time(t).UTC().Format(timestampPrintableFormat) + "+00"
 	switch verb {
 	case 't':
@@ -2064,6 +2057,11 @@ type IntervalValue struct {
 }
 
 func (iv *IntervalValue) Add(v Value) (Value, error) {
+	switch v.(type) {
+	case DateValue, DatetimeValue, TimestampValue:
+		// INTERVAL + DATE/DATETIME/TIMESTAMP is the same as DATE/DATETIME/TIMESTAMP + INTERVAL.
+		return v.Add(iv)
+	}
 	return nil, fmt.Errorf("unsupported add operator for interval value")
 }
 
@@ -2104,10 +2102,56 @@ func (iv *IntervalValue) ToInt64() (int64, error) {
 }
 
 func (iv *IntervalValue) ToString() (string, error) {
-	if iv.Years == 0 && iv.Months < 0 {
-		return "-" + iv.String(), nil
+	// bigquery.IntervalValue.String drops the sign of the Y-M part or the time part
+	// when its leading field is zero ( e.g. INTERVAL -30 MINUTE ), so format it here.
+	v := iv.Canonicalize()
+	var ymSign, timeSign string
+	if v.Years < 0 || v.Months < 0 {
+		ymSign = "-"
+	}
+	if v.Hours < 0 || v.Minutes < 0 || v.Seconds < 0 || v.SubSecondNanos < 0 {
+		timeSign = "-"
+	}
+	ret := fmt.Sprintf(
+		"%s%d-%d %d %s%d:%d:%d",
+		ymSign, absInt32(v.Years), absInt32(v.Months),
+		v.Days,
+		timeSign, absInt32(v.Hours), absInt32(v.Minutes), absInt32(v.Seconds),
+	)
+	if v.SubSecondNanos != 0 {
+		ret += "." + strings.TrimRight(fmt.Sprintf("%09d", absInt32(v.SubSecondNanos)), "0")
+	}
+	return ret, nil
+}
+
+func absInt32(v int32) int32 {
+	if v < 0 {
+		return -v
 	}
-	return iv.String(), nil
+	return v
+}
+
+// addInterval adds ( sign = 1 ) or subtracts ( sign = -1 ) the interval to the time.
+// The Y-M part is applied first and the day is clamped to the last day of the resulting month
+// ( e.g. 2023-01-31 + INTERVAL 1 MONTH = 2023-02-28 ), then the days and the time part are applied.
+func addInterval(t time.Time, iv *IntervalValue, sign int) time.Time {
+	year, month, day := t.Date()
+	hour, minute, second := t.Clock()
+	months := sign * (int(iv.Years)*12 + int(iv.Months))
+	first := time.Date(year, month+time.Month(months), 1, 0, 0, 0, 0, t.Location())
+	if lastDay := first.AddDate(0, 1, -1).Day(); day > lastDay {
+		day = lastDay
+	}
+	return time.Date(
+		first.Year(),
+		first.Month(),
+		day+sign*int(iv.Days),
+		hour+sign*int(iv.Hours),
+		minute+sign*int(iv.Minutes),
+		second+sign*int(iv.Seconds),
+		t.Nanosecond()+sign*int(iv.SubSecondNanos),
+		t.Location(),
+	)
 }
 
 func (iv *IntervalValue) ToBytes() ([]byte, error) {
@@ -2155,6 +2199,9 @@ func (iv *IntervalValue) Format(verb rune) string {
 	if err != nil {
 		return ""
 	}
+	if verb == 'T' {
+		return fmt.Sprintf(`INTERVAL %q YEAR TO SECOND`, s)
+	}
 	return s
 }
 
@@ -2166,6 +2213,282 @@ func (iv *IntervalValue) Interface() interface{} {
 	return s
 }
 
+// GeographyValue is a value of GEOGRAPHY type and it is represented by WKT ( e.g. POINT(1 2) ).
+// Only a single POINT, LINESTRING or POLYGON is supported currently.
+type GeographyValue struct {
+	Shape GeographyShape
+
+	// Coordinates has a single point for POINT, the vertices for LINESTRING and the rings for POLYGON.
+	// It is empty for the empty geography.
+	Coordinates [][]*GeographyPoint
+}
+
+func (gv *GeographyValue) Add(v Value) (Value, error) {
+	return nil, fmt.Errorf("add operation is unsupported for geography %v", gv)
+}
+
+func (gv *GeographyValue) Sub(v Value) (Value, error) {
+	return nil, fmt.Errorf("sub operation is unsupported for geography %v", gv)
+}
+
+func (gv *GeographyValue) Mul(v Value) (Value, error) {
+	return nil, fmt.Errorf("mul operation is unsupported for geography %v", gv)
+}
+
+func (gv *GeographyValue) Div(v Value) (Value, error) {
+	return nil, fmt.Errorf("div operation is unsupported for geography %v", gv)
+}
+
+func (gv *GeographyValue) EQ(v Value) (bool, error) {
+	return false, fmt.Errorf("eq operation is unsupported for geography %v", gv)
+}
+
+func (gv *GeographyValue) GT(v Value) (bool, error) {
+	return false, fmt.Errorf("gt operation is unsupported for geography %v", gv)
+}
+
+func (gv *GeographyValue) GTE(v Value) (bool, error) {
+	return false, fmt.Errorf("gte operation is unsupported for geography %v", gv)
+}
+
+func (gv *GeographyValue) LT(v Value) (bool, error) {
+	return false, fmt.Errorf("lt operation is unsupported for geography %v", gv)
+}
+
+func (gv *GeographyValue) LTE(v Value) (bool, error) {
+	return false, fmt.Errorf("lte operation is unsupported for geography %v", gv)
+}
+
+func (gv *GeographyValue) ToInt64() (int64, error) {
+	return 0, fmt.Errorf("failed to convert int64 from geography %v", gv)
+}
+
+func (gv *GeographyValue) ToString() (string, error) {
+	if len(gv.Coordinates) == 0 {
+		return "GEOMETRYCOLLECTION EMPTY", nil
+	}
+	rings := make([]string, 0, len(gv.Coordinates))
+	for _, ring := range gv.Coordinates {
+		points := make([]string, 0, len(ring))
+		for _, point := range ring {
+			points = append(points, point.String())
+		}
+		rings = append(rings, strings.Join(points, ", "))
+	}
+	if gv.Shape == GeographyPolygonShape {
+		return fmt.Sprintf("%s((%s))", gv.Shape, strings.Join(rings, "), (")), nil
+	}
+	return fmt.Sprintf("%s(%s)", gv.Shape, rings[0]), nil
+}
+
+func (gv *GeographyValue) ToBytes() ([]byte, error) {
+	s, err := gv.ToString()
+	if err != nil {
+		return nil, err
+	}
+	return []byte(s), nil
+}
+
+func (gv *GeographyValue) ToFloat64() (float64, error) {
+	return 0, fmt.Errorf("failed to convert float64 from geography %v", gv)
+}
+
+func (gv *GeographyValue) ToBool() (bool, error) {
+	return false, fmt.Errorf("failed to convert bool from geography %v", gv)
+}
+
+func (gv *GeographyValue) ToArray() (*ArrayValue, error) {
+	return nil, fmt.Errorf("failed to convert array from geography %v", gv)
+}
+
+func (gv *GeographyValue) ToStruct() (*StructValue, error) {
+	return nil, fmt.Errorf("failed to convert struct from geography %v", gv)
+}
+
+func (gv *GeographyValue) ToJSON() (string, error) {
+	s, err := gv.ToString()
+	if err != nil {
+		return "", err
+	}
+	return strconv.Quote(s), nil
+}
+
+func (gv *GeographyValue) ToTime() (time.Time, error) {
+	return time.Time{}, fmt.Errorf("failed to convert time.Time from geography %v", gv)
+}
+
+func (gv *GeographyValue) ToRat() (*big.Rat, error) {
+	return nil, fmt.Errorf("failed to convert *big.Rat from geography %v", gv)
+}
+
+func (gv *GeographyValue) Format(verb rune) string {
+	s, _ := gv.ToString()
+	switch verb {
+	case 'T':
+		return fmt.Sprintf("ST_GEOGFROMTEXT(%s)", strconv.Quote(s))
+	}
+	return s
+}
+
+func (gv *GeographyValue) Interface() interface{} {
+	s, err := gv.ToString()
+	if err != nil {
+		return nil
+	}
+	return s
+}
+
+func (gv *GeographyValue) String() string {
+	s, _ := gv.ToString()
+	return s
+}
+
+// RangeValue is a value of RANGE<DATE>, RANGE<DATETIME> or RANGE<TIMESTAMP> type.
+// It represents the half-open interval [Start, End).
+// Start and End are nil if the boundary is unbounded.
+type RangeValue struct {
+	Start Value
+	End   Value
+}
+
+func (rv *RangeValue) Add(v Value) (Value, error) {
+	return nil, fmt.Errorf("add operation is unsupported for range %v", rv)
+}
+
+func (rv *RangeValue) Sub(v Value) (Value, error) {
+	return nil, fmt.Errorf("sub operation is unsupported for range %v", rv)
+}
+
+func (rv *RangeValue) Mul(v Value) (Value, error) {
+	return nil, fmt.Errorf("mul operation is unsupported for range %v", rv)
+}
+
+func (rv *RangeValue) Div(v Value) (Value, error) {
+	return nil, fmt.Errorf("div operation is unsupported for range %v", rv)
+}
+
+func (rv *RangeValue) EQ(v Value) (bool, error) {
+	v2, ok := v.(*RangeValue)
+	if !ok {
+		return false, fmt.Errorf("failed to compare range with %T", v)
+	}
+	startEQ, err := rangeBoundaryEQ(rv.Start, v2.Start)
+	if err != nil {
+		return false, err
+	}
+	if !startEQ {
+		return false, nil
+	}
+	return rangeBoundaryEQ(rv.End, v2.End)
+}
+
+func rangeBoundaryEQ(a, b Value) (bool, error) {
+	if a == nil || b == nil {
+		return a == nil && b == nil, nil
+	}
+	return a.EQ(b)
+}
+
+func (rv *RangeValue) GT(v Value) (bool, error) {
+	return false, fmt.Errorf("gt operation is unsupported for range %v", rv)
+}
+
+func (rv *RangeValue) GTE(v Value) (bool, error) {
+	return false, fmt.Errorf("gte operation is unsupported for range %v", rv)
+}
+
+func (rv *RangeValue) LT(v Value) (bool, error) {
+	return false, fmt.Errorf("lt operation is unsupported for range %v", rv)
+}
+
+func (rv *RangeValue) LTE(v Value) (bool, error) {
+	return false, fmt.Errorf("lte operation is unsupported for range %v", rv)
+}
+
+func (rv *RangeValue) ToInt64() (int64, error) {
+	return 0, fmt.Errorf("failed to convert int64 from range %v", rv)
+}
+
+func (rv *RangeValue) ToString() (string, error) {
+	return fmt.Sprintf("[%s, %s)", rangeBoundaryString(rv.Start), rangeBoundaryString(rv.End)), nil
+}
+
+func rangeBoundaryString(v Value) string {
+	if v == nil {
+		return "UNBOUNDED"
+	}
+	return v.Format('t')
+}
+
+func (rv *RangeValue) ToBytes() ([]byte, error) {
+	s, err := rv.ToString()
+	if err != nil {
+		return nil, err
+	}
+	return []byte(s), nil
+}
+
+func (rv *RangeValue) ToFloat64() (float64, error) {
+	return 0, fmt.Errorf("failed to convert float64 from range %v", rv)
+}
+
+func (rv *RangeValue) ToBool() (bool, error) {
+	return false, fmt.Errorf("failed to convert bool from range %v", rv)
+}
+
+func (rv *RangeValue) ToArray() (*ArrayValue, error) {
+	return nil, fmt.Errorf("failed to convert array from range %v", rv)
+}
+
+func (rv *RangeValue) ToStruct() (*StructValue, error) {
+	return nil, fmt.Errorf("failed to convert struct from range %v", rv)
+}
+
+func (rv *RangeValue) ToJSON() (string, error) {
+	s, err := rv.ToString()
+	if err != nil {
+		return "", err
+	}
+	return strconv.Quote(s), nil
+}
+
+func (rv *RangeValue) ToTime() (time.Time, error) {
+	return time.Time{}, fmt.Errorf("failed to convert time.Time from range %v", rv)
+}
+
+func (rv *RangeValue) ToRat() (*big.Rat, error) {
+	return nil, fmt.Errorf("failed to convert *big.Rat from range %v", rv)
+}
+
+func (rv *RangeValue) Format(verb rune) string {
+	switch verb {
+	case 'T':
+		return fmt.Sprintf("RANGE(%s, %s)", rangeBoundaryLiteral(rv.Start), rangeBoundaryLiteral(rv.End))
+	}
+	s, _ := rv.ToString()
+	return s
+}
+
+func rangeBoundaryLiteral(v Value) string {
+	if v == nil {
+		return "NULL"
+	}
+	return v.Format('T')
+}
+
+func (rv *RangeValue) Interface() interface{} {
+	s, err := rv.ToString()
+	if err != nil {
+		return nil
+	}
+	return s
+}
+
+func (rv *RangeValue) String() string {
+	s, _ := rv.ToString()
+	return s
+}
+
 type SafeValue struct {
 	value Value
 }
@@ -2439,9 +2762,25 @@ func parseInterval(v string) (*IntervalValue, error) {
 	if isNegative && interval.Months > 0 {
 		interval.Months *= -1
 	}
+	// bigquery.ParseInterval takes the sign of the time part from the hours field,
+	// so the sign of a time part like -0:30:0 must be applied here.
+	if parts := strings.Fields(v); len(parts) == 3 && strings.HasPrefix(parts[2], "-") && interval.Hours == 0 {
+		interval.Minutes *= -1
+		interval.Seconds *= -1
+		interval.SubSecondNanos *= -1
+	}
 	return &IntervalValue{IntervalValue: interval}, nil
 }
 
+// IntervalFromString parses the string representation of INTERVAL type ( e.g. "1-2 3 -4:5:6.789" ).
+func IntervalFromString(v string) (*bigquery.IntervalValue, error) {
+	iv, err := parseInterval(v)
+	if err != nil {
+		return nil, err
+	}
+	return iv.IntervalValue, nil
+}
+
 func isNullValue(v interface{}) bool {
 	if v == nil {
 		return true
@@ -2452,5 +2791,8 @@ func isNullValue(v interface{}) bool {
 			return true
 		}
 	}
+	if rv.Kind() == reflect.Ptr && rv.IsNil() {
+		return true
+	}
 	return false
 }
@@ -3,7 +3,6 @@ package internal
 import (
 	"context"
 	"fmt"
-	"regexp"
 	"sort"
 	"strings"
 	"time"
@@ -26,9 +25,9 @@ func (c *Catalog) isWildcardTable(path []string) bool {
 }
 
 type WildcardTable struct {
-	spec   *TableSpec
-	tables []*TableSpec
-	prefix string
+	spec     *TableSpec
+	tables   []*TableSpec
+	suffixes []string
 }
 
 func (t *WildcardTable) existsColumn(table *TableSpec, column string) bool {
@@ -42,7 +41,7 @@ func (t *WildcardTable) existsColumn(table *TableSpec, column string) bool {
 
 func (t *WildcardTable) FormatSQL(ctx context.Context) (string, error) {
 	queries := make([]string, 0, len(t.tables))
-	for _, table := range t.tables {
+	for idx, table := range t.tables {
 		var columns []string
 		for _, column := range t.spec.Columns {
 			if column.Name == tableSuffixColumnName {
@@ -51,15 +50,10 @@ func (t *WildcardTable) FormatSQL(ctx context.Context) (string, error) {
 			if t.existsColumn(table, column.Name) {
 				columns = append(columns, fmt.Sprintf("`%s`", column.Name))
 			} else {
-				columns = append(columns, fmt.Sprintf("NULL as %s", column.Name))
+				columns = append(columns, fmt.Sprintf("NULL as `%s`", column.Name))
 			}
 		}
-		fullName := strings.Join(table.NamePath, ".")
-		if len(fullName) <= len(t.prefix) {
-			return "", fmt.Errorf("failed to find table suffix from %s", fullName)
-		}
-		tableSuffix := fullName[len(t.prefix):]
-		encodedSuffix, err := EncodeGoValue(types.StringType(), tableSuffix)
+		encodedSuffix, err := EncodeGoValue(types.StringType(), t.suffixes[idx])
 		if err != nil {
 			return "", err
 		}
@@ -105,7 +99,8 @@ func (t *WildcardTable) PrimaryKey() []int {
 
 func (t *WildcardTable) FindColumnByName(name string) types.Column {
 	for _, col := range t.spec.Columns {
-		if col.Name == name {
+		// column names are case insensitive ( e.g. _table_suffix ).
+		if strings.EqualFold(col.Name, name) {
 			typ, err := col.Type.ToZetaSQLType()
 			if err != nil {
 				return nil
@@ -143,49 +138,66 @@ func (t *WildcardTable) TableTypeName(mode types.ProductMode) string {
 }
 
 func (c *Catalog) createWildcardTable(path []string) (types.Table, error) {
-	name := strings.Join(path, "_")
-	name = strings.TrimRight(name, "*")
-	re, err := regexp.Compile(name)
-	if err != nil {
-		return nil, fmt.Errorf("failed to compile %s: %w", name, err)
+	// the path may be quoted as a whole ( e.g. `project.dataset.table_*` ) or per identifier.
+	var normalizedPath []string
+	for _, p := range path {
+		normalizedPath = append(normalizedPath, strings.Split(p, ".")...)
+	}
+	prefix := strings.TrimSuffix(strings.Join(normalizedPath, "."), "*")
+
+	type matchedTable struct {
+		spec   *TableSpec
+		suffix string
 	}
-	matchedSpecs := make([]*TableSpec, 0, len(c.tableMap))
-	for name, spec := range c.tableMap {
-		if re.MatchString(name) {
-			matchedSpecs = append(matchedSpecs, spec)
+	matchedTables := make([]*matchedTable, 0, len(c.tableMap))
+	for _, spec := range c.tableMap {
+		if spec.IsView {
+			continue
 		}
+		fullName := strings.Join(spec.NamePath, ".")
+		tablePrefix := prefix
+		if !strings.HasPrefix(fullName, tablePrefix) {
+			// firstIdentifier may be omitted, so we need to check it.
+			tablePrefix = spec.NamePath[0] + "." + prefix
+		}
+		if !strings.HasPrefix(fullName, tablePrefix) || len(fullName) == len(tablePrefix) {
+			continue
+		}
+		matchedTables = append(matchedTables, &matchedTable{
+			spec:   spec,
+			suffix: fullName[len(tablePrefix):],
+		})
 	}
-	sort.Slice(matchedSpecs, func(i, j int) bool {
-		return matchedSpecs[i].CreatedAt.UnixNano() > matchedSpecs[j].CreatedAt.UnixNano()
+	sort.Slice(matchedTables, func(i, j int) bool {
+		return matchedTables[i].spec.CreatedAt.UnixNano() > matchedTables[j].spec.CreatedAt.UnixNano()
 	})
-	if len(matchedSpecs) == 0 {
+	if len(matchedTables) == 0 {
 		return nil, fmt.Errorf("failed to find matched tables by wildcard")
 	}
 
-	spec := matchedSpecs[0]
+	spec := matchedTables[0].spec
 	wildcardTable := new(TableSpec)
 	*wildcardTable = *spec
 	wildcardTable.NamePath = append([]string{}, spec.NamePath...)
-	wildcardTable.Columns = append(wildcardTable.Columns, &ColumnSpec{
+	// copy columns so that the pseudo column is never appended to the original table spec.
+	wildcardTable.Columns = append(append([]*ColumnSpec{}, spec.Columns...), &ColumnSpec{
 		Name: tableSuffixColumnName,
 		Type: &Type{Kind: types.STRING},
 	})
-	lastNamePath := spec.NamePath[len(spec.NamePath)-1]
-	lastNamePath = lastNamePath[:len(path)-1]
+	lastNamePath := strings.TrimSuffix(normalizedPath[len(normalizedPath)-1], "*")
 	wildcardTable.NamePath[len(spec.NamePath)-1] = fmt.Sprintf(
 		"%s_wildcard_%d", lastNamePath, time.Now().Unix(),
 	)
 
-	// firstIdentifier may be omitted, so we need to check it.
-	prefix := name
-	firstIdentifier := spec.NamePath[0]
-	if !strings.HasPrefix(prefix, firstIdentifier+".") {
-		prefix = firstIdentifier + "." + prefix
+	tables := make([]*TableSpec, 0, len(matchedTables))
+	suffixes := make([]string, 0, len(matchedTables))
+	for _, table := range matchedTables {
+		tables = append(tables, table.spec)
+		suffixes = append(suffixes, table.suffix)
 	}
-
 	return &WildcardTable{
-		spec:   wildcardTable,
-		tables: matchedSpecs,
-		prefix: prefix,
+		spec:     wildcardTable,
+		tables:   tables,
+		suffixes: suffixes,
 	}, nil
 }
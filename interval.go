@@ -0,0 +1,72 @@
+package zetasqlite
+
+import (
+	"database/sql/driver"
+	"fmt"
+
+	"cloud.google.com/go/bigquery"
+
+	internal "github.com/goccy/go-zetasqlite/internal"
+)
+
+// Interval represents a value of INTERVAL type.
+// zetasqlite returns string values by default for interval values ( e.g. "1-2 3 4:5:6" ),
+// so Interval can be used as a scan destination for them and as a query parameter for INTERVAL columns.
+type Interval struct {
+	Years          int32
+	Months         int32
+	Days           int32
+	Hours          int32
+	Minutes        int32
+	Seconds        int32
+	SubSecondNanos int32
+}
+
+// Scan implements sql.Scanner interface.
+func (i *Interval) Scan(src interface{}) error {
+	var s string
+	switch v := src.(type) {
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	default:
+		return fmt.Errorf("cannot scan %T value into Interval", src)
+	}
+	iv, err := internal.IntervalFromString(s)
+	if err != nil {
+		return fmt.Errorf("failed to parse interval value %q: %w", s, err)
+	}
+	*i = Interval{
+		Years:          iv.Years,
+		Months:         iv.Months,
+		Days:           iv.Days,
+		Hours:          iv.Hours,
+		Minutes:        iv.Minutes,
+		Seconds:        iv.Seconds,
+		SubSecondNanos: iv.SubSecondNanos,
+	}
+	return nil
+}
+
+// Value implements driver.Valuer interface.
+func (i Interval) Value() (driver.Value, error) {
+	return i.String(), nil
+}
+
+// String returns the canonical string representation of INTERVAL type.
+func (i Interval) String() string {
+	iv := &internal.IntervalValue{
+		IntervalValue: &bigquery.IntervalValue{
+			Years:          i.Years,
+			Months:         i.Months,
+			Days:           i.Days,
+			Hours:          i.Hours,
+			Minutes:        i.Minutes,
+			Seconds:        i.Seconds,
+			SubSecondNanos: i.SubSecondNanos,
+		},
+	}
+	s, _ := iv.ToString()
+	return s
+}
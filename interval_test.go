@@ -0,0 +1,60 @@
+package zetasqlite_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	zetasqlite "github.com/goccy/go-zetasqlite"
+)
+
+func TestInterval(t *testing.T) {
+	db, err := sql.Open("zetasqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if _, err := db.Exec(`CREATE TABLE intervals (id INT64, value INTERVAL)`); err != nil {
+		t.Fatal(err)
+	}
+	inserted := zetasqlite.Interval{
+		Years:          1,
+		Months:         2,
+		Days:           -3,
+		Hours:          -4,
+		Minutes:        -5,
+		Seconds:        -6,
+		SubSecondNanos: -789000000,
+	}
+	if _, err := db.Exec(`INSERT intervals (id, value) VALUES (1, @value), (2, NULL)`, sql.Named("value", inserted)); err != nil {
+		t.Fatal(err)
+	}
+	t.Run("scan", func(t *testing.T) {
+		var (
+			value zetasqlite.Interval
+			text  string
+		)
+		if err := db.QueryRow(`SELECT value, CAST(value AS STRING) FROM intervals WHERE id = 1`).Scan(&value, &text); err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(inserted, value); diff != "" {
+			t.Errorf("(-want +got):\n%s", diff)
+		}
+		if text != "1-2 -3 -4:5:6.789" {
+			t.Errorf("unexpected interval text %q", text)
+		}
+		if value.String() != text {
+			t.Errorf("expected %q but got %q", text, value.String())
+		}
+	})
+	t.Run("scan null", func(t *testing.T) {
+		var value *zetasqlite.Interval
+		if err := db.QueryRow(`SELECT value FROM intervals WHERE id = 2`).Scan(&value); err != nil {
+			t.Fatal(err)
+		}
+		if value != nil {
+			t.Fatalf("expected null interval but got %s", value)
+		}
+	})
+}
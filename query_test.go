@@ -61,6 +61,32 @@ UNION ALL
 (WITH toks2 AS (SELECT 2 AS x) SELECT COUNT(x) AS total_rows FROM toks2 WHERE x > 0 HAVING total_rows >= 0)`,
 			expectedRows: [][]interface{}{{int64(1)}, {int64(1)}},
 		},
+		{
+			name: "trailing comma in select list",
+			query: `
+WITH t AS (SELECT 1 AS a, 'x' AS b)
+SELECT
+  a,
+  b AS last_b,
+FROM t`,
+			expectedRows: [][]interface{}{{int64(1), "x"}},
+		},
+		{
+			name:         "trailing comma in select list with aggregation",
+			query:        `WITH t AS (SELECT 1 AS a UNION ALL SELECT 1) SELECT a, COUNT(*), FROM t GROUP BY a`,
+			expectedRows: [][]interface{}{{int64(1), int64(2)}},
+		},
+		// BigQuery only allows a trailing comma at the end of the select list.
+		{
+			name:        "trailing comma in group by",
+			query:       `WITH t AS (SELECT 1 AS a) SELECT a FROM t GROUP BY a,`,
+			expectedErr: "Syntax error",
+		},
+		{
+			name:        "trailing comma in function arguments",
+			query:       `SELECT CONCAT('a', 'b',)`,
+			expectedErr: "Syntax error",
+		},
 		// priority 2 operator
 		{
 			name:         "unary plus operator",
@@ -98,6 +124,16 @@ UNION ALL
 			query:        `SELECT [1, 2] || [3, 4]`,
 			expectedRows: [][]interface{}{{[]interface{}{int64(1), int64(2), int64(3), int64(4)}}},
 		},
+		{
+			name:         "concat operator with null",
+			query:        `SELECT "a" || NULL || "b", b"a" || NULL, [1, 2] || NULL`,
+			expectedRows: [][]interface{}{{nil, nil, nil}},
+		},
+		{
+			name:         "concat bytes operator",
+			query:        `SELECT FORMAT('%T', b'\x00' || b'\x01'), BYTE_LENGTH(b'\xff' || b'\xfe')`,
+			expectedRows: [][]interface{}{{`b"\x00\x01"`, int64(2)}},
+		},
 
 		// priority 4 operator
 		{
@@ -237,6 +273,26 @@ UNION ALL
 			query:        `SELECT 1 IS NOT NULL`,
 			expectedRows: [][]interface{}{{true}},
 		},
+		{
+			name: "is null operator with struct",
+			query: `SELECT s IS NULL, s IS NOT NULL FROM UNNEST([
+  STRUCT(STRUCT(1 AS a, 'x' AS b) AS s),
+  (STRUCT(CAST(NULL AS INT64) AS a, CAST(NULL AS STRING) AS b)),
+  (NULL)
+])`,
+			expectedRows: [][]interface{}{{false, true}, {false, true}, {true, false}},
+		},
+		{
+			name:         "is null operator with array",
+			query:        `SELECT [1] IS NULL, CAST([] AS ARRAY<INT64>) IS NULL, [CAST(NULL AS INT64)] IS NULL, CAST(NULL AS ARRAY<INT64>) IS NULL, CAST(NULL AS ARRAY<INT64>) IS NOT NULL`,
+			expectedRows: [][]interface{}{{false, false, false, true, false}},
+		},
+		{
+			name: "is null operator with struct field",
+			query: `WITH t AS (SELECT STRUCT(CAST(NULL AS INT64) AS a) AS s UNION ALL SELECT NULL)
+SELECT s IS NULL, s.a IS NULL FROM t`,
+			expectedRows: [][]interface{}{{false, true}, {true, true}},
+		},
 		{
 			name:         "is true operator",
 			query:        `SELECT true IS TRUE`,
@@ -454,6 +510,11 @@ FROM UNNEST([1, 2, 3, 4]) AS val`,
 				{int64(4), "four"},
 			},
 		},
+		{
+			name:         "cast double to float",
+			query:        `SELECT CAST(0.1 AS FLOAT), CAST(16777217 AS FLOAT), CAST(0.1 AS FLOAT64)`,
+			expectedRows: [][]interface{}{{float64(float32(0.1)), float64(16777216), float64(0.1)}},
+		},
 		{
 			name:         "coalesce",
 			query:        `SELECT COALESCE('A', 'B', 'C')`,
@@ -464,6 +525,16 @@ FROM UNNEST([1, 2, 3, 4]) AS val`,
 			query:        `SELECT COALESCE(NULL, 'B', 'C')`,
 			expectedRows: [][]interface{}{{"B"}},
 		},
+		{
+			name:         "coalesce with coercible date and datetime",
+			query:        `SELECT COALESCE(DATE '2020-01-01', DATETIME '2021-02-03 04:05:06')`,
+			expectedRows: [][]interface{}{{"2020-01-01T00:00:00"}},
+		},
+		{
+			name:        "coalesce with incompatible date and timestamp",
+			query:       `SELECT COALESCE(DATE '2020-01-01', TIMESTAMP '2021-02-03 04:05:06 UTC')`,
+			expectedErr: "No matching signature for function COALESCE for argument types: DATE, TIMESTAMP",
+		},
 		{
 			name:         "coalesce with all nulls",
 			query:        `SELECT COALESCE(NULL, NULL, NULL)`,
@@ -509,11 +580,93 @@ FROM UNNEST([1, 2, 3, 4]) AS val`,
 			query:        `SELECT ROUND(2.0), ROUND(2.3), ROUND(2.8), ROUND(2.5), ROUND(-2.3), ROUND(-2.8), ROUND(-2.5)`,
 			expectedRows: [][]interface{}{{float64(2.0), float64(2.0), float64(3.0), float64(3.0), float64(-2.0), float64(-3.0), float64(-3.0)}},
 		},
+		{
+			// the bundled ZetaSQL doesn't have the signature of ROUND with rounding mode,
+			// so values are always rounded half away from zero.
+			name:        "rounding with half even mode",
+			query:       `SELECT ROUND(2.5, 0, 'ROUND_HALF_EVEN'), ROUND(NUMERIC '2.5', 0, 'ROUND_HALF_EVEN')`,
+			expectedErr: "No matching signature for function ROUND",
+		},
 		{
 			name:         "rounding precision",
 			query:        `SELECT ROUND(123.7, -1), ROUND(1.235, 2)`,
 			expectedRows: [][]interface{}{{float64(120.0), float64(1.24)}},
 		},
+		{
+			// ROUND has no INT64 signature, so INT64 values are coerced to FLOAT64 in the same way as BigQuery.
+			name:         "rounding integer with negative precision",
+			query:        `SELECT ROUND(12345, -2), ROUND(12345, -1), ROUND(125, -1), ROUND(-125, -1), ROUND(49, -2), ROUND(50, -2), ROUND(12345, -5)`,
+			expectedRows: [][]interface{}{{float64(12300), float64(12350), float64(130), float64(-130), float64(0), float64(100), float64(0)}},
+		},
+		{
+			name:         "rounding integer column with negative precision",
+			query:        `SELECT x, ROUND(x, -1), ROUND(CAST(x AS NUMERIC), -1) FROM UNNEST([14, 15, -15]) AS x`,
+			expectedRows: [][]interface{}{{int64(14), float64(10), "10"}, {int64(15), float64(20), "20"}, {int64(-15), float64(-20), "-20"}},
+		},
+		{
+			name:         "truncation",
+			query:        `SELECT TRUNC(2.8), TRUNC(-2.8), TRUNC(123.456, 2), TRUNC(-123.456, 1), TRUNC(0.29, 2), TRUNC(987.6, -2), TRUNC(1.5, NULL)`,
+			expectedRows: [][]interface{}{{float64(2), float64(-2), float64(123.45), float64(-123.4), float64(0.29), float64(900), nil}},
+		},
+		{
+			name: "truncation numeric",
+			query: `
+SELECT
+  TRUNC(NUMERIC '2.8'),
+  TRUNC(NUMERIC '-2.8'),
+  TRUNC(NUMERIC '1.239', 2),
+  TRUNC(NUMERIC '-1.239', 2),
+  TRUNC(NUMERIC '987.6', -2),
+  TRUNC(BIGNUMERIC '12345678901234567890.123456789012345', 10)`,
+			expectedRows: [][]interface{}{{"2", "-2", "1.23", "-1.23", "900", "12345678901234567890.123456789"}},
+		},
+		{
+			name: "ceil and floor numeric",
+			query: `
+SELECT
+  CEIL(NUMERIC '1.1'), CEIL(NUMERIC '-1.1'), CEIL(NUMERIC '2'),
+  FLOOR(NUMERIC '1.9'), FLOOR(NUMERIC '-1.1'), FLOOR(NUMERIC '-2'),
+  CEIL(BIGNUMERIC '12345678901234567890.000000000000000001'),
+  FLOOR(BIGNUMERIC '-12345678901234567890.000000000000000001')`,
+			expectedRows: [][]interface{}{{"2", "-1", "2", "1", "-2", "-2", "12345678901234567891", "-12345678901234567891"}},
+		},
+		{
+			name: "rounding numeric",
+			query: `
+SELECT
+  ROUND(NUMERIC '2.5'),
+  ROUND(NUMERIC '-2.5'),
+  ROUND(NUMERIC '1.235', 2),
+  ROUND(NUMERIC '-1.235', 2),
+  ROUND(NUMERIC '125', -1),
+  ROUND(BIGNUMERIC '12345678901234567890.123456789012345', 10),
+  ROUND(NUMERIC '1.5', NULL),
+  ROUND(CAST('1.005' AS NUMERIC), 2)`,
+			expectedRows: [][]interface{}{{"3", "-3", "1.24", "-1.24", "130", "12345678901234567890.123456789", nil, "1.01"}},
+		},
+		{
+			name: "rounding numeric keeps numeric type",
+			query: `
+SELECT
+  FORMAT('%T', FLOOR(NUMERIC '1.5')),
+  FORMAT('%T', CEIL(NUMERIC '1.5')),
+  FORMAT('%T', ROUND(NUMERIC '1.55', 1)),
+  FORMAT('%T', TRUNC(BIGNUMERIC '1.55', 1)),
+  FORMAT('%T', ROUND(1.55, 1))`,
+			expectedRows: [][]interface{}{{`NUMERIC "1"`, `NUMERIC "2"`, `NUMERIC "1.6"`, `BIGNUMERIC "1.5"`, "1.6"}},
+		},
+		{
+			name: "rounding with huge precision",
+			query: `
+SELECT
+  ROUND(NUMERIC '1.5', 2000000000),
+  ROUND(NUMERIC '-125.5', -2000000000),
+  TRUNC(NUMERIC '123.4', -2000000000),
+  TRUNC(BIGNUMERIC '1.25', 2000000000),
+  ROUND(1.5, 2000000000),
+  TRUNC(123.4, -2000000000)`,
+			expectedRows: [][]interface{}{{"1.5", "0", "0", "1.25", float64(1.5), float64(0)}},
+		},
 		{
 			name: "with clause",
 			query: `
@@ -527,6 +680,19 @@ SELECT * FROM sub2`,
 				{[]interface{}{"c", "d"}},
 			},
 		},
+		{
+			// the bundled ZetaSQL parser does not accept a column list after the name of WITH clause entry.
+			name:        "with clause column aliases",
+			query:       `WITH t(a, b) AS (SELECT 1, 2) SELECT a, b FROM t`,
+			expectedErr: "Syntax error",
+		},
+		{
+			name: "with clause renames columns in subquery",
+			query: `
+WITH t AS (SELECT x AS a, y AS b FROM (SELECT 1 AS x, 2 AS y))
+SELECT b, a FROM t`,
+			expectedRows: [][]interface{}{{int64(2), int64(1)}},
+		},
 		{
 			name: "field access operator",
 			query: `
@@ -541,6 +707,25 @@ SELECT t.customer.address.country FROM orders AS t`,
 			query:        `SELECT CURRENT_TIMESTAMP() AS ts, STRUCT(NULL AS a, FALSE AS b).b AS b`,
 			expectedRows: [][]interface{}{{createTimestampFormatFromTime(now.UTC()), false}},
 		},
+		{
+			name:  "empty and single field struct",
+			query: `SELECT STRUCT(), STRUCT(1 AS a), STRUCT('x'), STRUCT(1 AS a).a`,
+			expectedRows: [][]interface{}{{
+				[]map[string]interface{}{},
+				[]map[string]interface{}{{"a": int64(1)}},
+				[]map[string]interface{}{{"": "x"}},
+				int64(1),
+			}},
+		},
+		{
+			name:  "single field struct from column",
+			query: `SELECT STRUCT(x AS a), STRUCT(x), STRUCT(x AS a).a FROM UNNEST([1]) AS x`,
+			expectedRows: [][]interface{}{{
+				[]map[string]interface{}{{"a": int64(1)}},
+				[]map[string]interface{}{{"": int64(1)}},
+				int64(1),
+			}},
+		},
 		{
 			name: "array index access operator",
 			query: `
@@ -564,6 +749,40 @@ FROM Items`,
 					SELECT digits[SAFE_ORDINAL(2)], digits[ORDINAL(2)], digits[OFFSET(1)], digits[SAFE_OFFSET(1)] FROM toks`,
 			expectedRows: [][]interface{}{{"two", "two", "two", "two"}},
 		},
+		{
+			name: "safe array access operator out of range",
+			query: `
+WITH Items AS (SELECT ["coffee", "tea", "milk"] AS item_array)
+SELECT
+  item_array[SAFE_OFFSET(3)],
+  item_array[SAFE_OFFSET(-1)],
+  item_array[SAFE_ORDINAL(0)],
+  item_array[SAFE_ORDINAL(4)],
+  item_array[SAFE_ORDINAL(-1)],
+  item_array[SAFE_OFFSET(NULL)],
+  CAST(NULL AS ARRAY<STRING>)[SAFE_ORDINAL(1)]
+FROM Items`,
+			expectedRows: [][]interface{}{{nil, nil, nil, nil, nil, nil, nil}},
+		},
+		{
+			name: "array index access operator without offset",
+			query: `
+WITH Items AS (SELECT ["coffee", "tea", "milk"] AS item_array)
+SELECT
+  item_array[0],
+  item_array[1 + 1],
+  [10, 20, 30][1]
+FROM Items`,
+			expectedRows: [][]interface{}{{"coffee", "milk", int64(20)}},
+		},
+		{
+			name: "array index access operator without offset out of range",
+			query: `
+WITH Items AS (SELECT ["coffee", "tea", "milk"] AS item_array)
+SELECT item_array[3] FROM Items`,
+			expectedRows: [][]interface{}{},
+			expectedErr:  "OFFSET(3) is out of range",
+		},
 		{
 			name: "create function",
 			query: `
@@ -683,6 +902,40 @@ FROM Items`,
 			query:        `SELECT b, ARRAY_AGG(a IGNORE NULLS) FROM UNNEST([STRUCT(NULL AS a, 2 AS b), STRUCT(1 AS a, 2 AS b)]) GROUP BY b`,
 			expectedRows: [][]interface{}{{int64(2), []interface{}{int64(1)}}},
 		},
+		{
+			name: "array_agg with distinct and ignore nulls",
+			query: `
+SELECT g, ARRAY_AGG(DISTINCT x IGNORE NULLS ORDER BY x), COUNT(DISTINCT x), COUNT(x)
+FROM UNNEST([
+  STRUCT('a' AS g, 2 AS x), ('a', NULL), ('a', 1), ('a', 2), ('a', NULL), ('a', 1),
+  ('b', NULL), ('b', 3), ('b', NULL), ('b', 3)
+])
+GROUP BY g ORDER BY g`,
+			expectedRows: [][]interface{}{
+				{"a", []interface{}{int64(1), int64(2)}, int64(2), int64(4)},
+				{"b", []interface{}{int64(3)}, int64(1), int64(2)},
+			},
+		},
+		{
+			name:  "array_agg with distinct, ignore nulls and limit",
+			query: `SELECT ARRAY_AGG(DISTINCT x IGNORE NULLS ORDER BY x DESC LIMIT 2) FROM UNNEST([NULL, 1, 3, NULL, 2, 3, 1]) AS x`,
+			expectedRows: [][]interface{}{{
+				[]interface{}{int64(3), int64(2)},
+			}},
+		},
+		{
+			name: "array_agg with distinct struct",
+			query: `
+SELECT ARRAY_AGG(DISTINCT STRUCT(a, b)), COUNT(DISTINCT STRUCT(a, b))
+FROM UNNEST([STRUCT(1 AS a, 'x' AS b), (1, 'y'), (1, 'x'), (1, 'y')])`,
+			expectedRows: [][]interface{}{{
+				[]interface{}{
+					[]map[string]interface{}{{"a": int64(1)}, {"b": "x"}},
+					[]map[string]interface{}{{"a": int64(1)}, {"b": "y"}},
+				},
+				int64(2),
+			}},
+		},
 		{
 			name:  "array_agg with abs",
 			query: `SELECT ARRAY_AGG(x ORDER BY ABS(x)) AS array_agg FROM UNNEST([2, 1, -2, 3, -2, 1, 2]) AS x`,
@@ -798,6 +1051,20 @@ SELECT ARRAY_CONCAT_AGG(x) AS array_concat_agg FROM (
 			query:        `SELECT COUNT(x) FROM UNNEST([NULL]) AS x`,
 			expectedRows: [][]interface{}{{int64(0)}},
 		},
+		{
+			name: "count with nan",
+			query: `
+SELECT COUNT(x), COUNT(*), COUNTIF(IS_NAN(x)), IS_NAN(SUM(x)), IS_NAN(AVG(x))
+FROM UNNEST([1.0, CAST('NaN' AS FLOAT64), NULL, IEEE_DIVIDE(0, 0)]) AS x`,
+			expectedRows: [][]interface{}{{int64(3), int64(4), int64(2), true, true}},
+		},
+		{
+			name: "nan is not null",
+			query: `
+WITH t AS (SELECT IEEE_DIVIDE(0, 0) AS x UNION ALL SELECT 2.0)
+SELECT x IS NULL, IS_NAN(x) FROM t ORDER BY IS_NAN(x) DESC`,
+			expectedRows: [][]interface{}{{false, true}, {false, false}},
+		},
 		{
 			name:         "count with if",
 			query:        `SELECT COUNT(DISTINCT IF(x > 0, x, NULL)) AS distinct_positive FROM UNNEST([1, -2, 4, 1, -5, 4, 1, 3, -6, 1]) AS x`,
@@ -813,6 +1080,25 @@ SELECT ARRAY_CONCAT_AGG(x) AS array_concat_agg FROM (
 				{int64(5), int64(1), int64(1)},
 			},
 		},
+		{
+			name: "count and count star with null values in window frame",
+			query: `
+SELECT
+  id,
+  COUNT(*) OVER (ORDER BY id ROWS UNBOUNDED PRECEDING),
+  COUNT(x) OVER (ORDER BY id ROWS UNBOUNDED PRECEDING),
+  COUNT(*) OVER (ORDER BY id ROWS BETWEEN 1 PRECEDING AND CURRENT ROW),
+  COUNT(x) OVER (ORDER BY id ROWS BETWEEN 1 PRECEDING AND CURRENT ROW)
+FROM UNNEST([STRUCT(1 AS id, NULL AS x), (2, 5), (3, NULL), (4, 7), (5, 7)])
+ORDER BY id`,
+			expectedRows: [][]interface{}{
+				{int64(1), int64(1), int64(0), int64(1), int64(0)},
+				{int64(2), int64(2), int64(1), int64(2), int64(1)},
+				{int64(3), int64(3), int64(1), int64(2), int64(1)},
+				{int64(4), int64(4), int64(2), int64(2), int64(1)},
+				{int64(5), int64(5), int64(3), int64(2), int64(2)},
+			},
+		},
 		{
 			name:         "countif",
 			query:        `SELECT COUNTIF(x<0) AS num_negative, COUNTIF(x>0) AS num_positive FROM UNNEST([5, -2, 3, 6, -10, -7, 4, 0]) AS x`,
@@ -891,6 +1177,17 @@ SELECT LOGICAL_OR(x) AS logical_or FROM toks`,
 			query:        `SELECT MIN(x) OVER() AS max FROM UNNEST(['2022-01-01', '2022-02-01', '2022-01-02', '2021-03-01']) AS x`,
 			expectedRows: [][]interface{}{{"2021-03-01"}, {"2021-03-01"}, {"2021-03-01"}, {"2021-03-01"}},
 		},
+		{
+			name:         "max and min from string group use byte order",
+			query:        `SELECT MAX(x), MIN(x) FROM UNNEST(['apple', 'Zebra', 'éclair', 'banana', NULL]) AS x`,
+			expectedRows: [][]interface{}{{"éclair", "Zebra"}},
+		},
+		// TODO: currently COLLATE doesn't attach the collation to the value, so MIN/MAX always compare strings by bytes.
+		// {
+		//	name:         "min from string group with collation",
+		//	query:        `SELECT MIN(COLLATE(x, 'und:ci')) FROM UNNEST(['b', 'A', 'a', 'C']) AS x`,
+		//	expectedRows: [][]interface{}{{"A"}},
+		// },
 		{
 			name:         "string_agg",
 			query:        `SELECT STRING_AGG(fruit) AS string_agg FROM UNNEST(["apple", NULL, "pear", "banana", "pear"]) AS fruit`,
@@ -987,11 +1284,37 @@ SELECT LOGICAL_OR(x) AS logical_or FROM toks`,
 			query:        `SELECT SUM(x) AS sum FROM UNNEST([]) AS x`,
 			expectedRows: [][]interface{}{{nil}},
 		},
+		{
+			name:         "sum float64 with compensated summation",
+			query:        `SELECT SUM(0.1), AVG(0.1) FROM UNNEST(GENERATE_ARRAY(1, 10))`,
+			expectedRows: [][]interface{}{{float64(1), float64(0.1)}},
+		},
+		{
+			name:         "sum float64 with cancellation",
+			query:        `SELECT SUM(x) FROM UNNEST([1e100, 1.0, -1e100]) AS x`,
+			expectedRows: [][]interface{}{{float64(1)}},
+		},
+		{
+			name:         "sum float64 with window and cancellation",
+			query:        `SELECT s FROM (SELECT x, SUM(x) OVER () AS s FROM UNNEST([1e100, 1.0, -1e100]) AS x) WHERE x = 1`,
+			expectedRows: [][]interface{}{{float64(1)}},
+		},
+		{
+			name:         "sum float64 with infinity",
+			query:        `SELECT SUM(x) FROM UNNEST([1.0, CAST('inf' AS FLOAT64), 2.0]) AS x`,
+			expectedRows: [][]interface{}{{math.Inf(1)}},
+		},
 		{
 			name:        "safe sum",
 			query:       `SELECT SAFE.SUM(x) AS sum FROM UNNEST([1, 2, 3, 4, 5, 4, 3, 2, 1]) AS x`,
 			expectedErr: "SAFE is not supported for function SUM",
 		},
+		{
+			name: "safe prefix for scalar functions",
+			query: `SELECT SAFE.PARSE_JSON('{"a" 1}'), SAFE.ST_GEOGPOINT(0, 91), SAFE.NET.IPV4_FROM_INT64(0x100000000),
+ SAFE.ABS(-9223372036854775808), SAFE.SUBSTR('abc', 2), SAFE.ST_GEOGFROMTEXT('POINT(1 2')`,
+			expectedRows: [][]interface{}{{nil, nil, nil, nil, "bc", nil}},
+		},
 		{
 			name:         "approx_count_distinct",
 			query:        `SELECT APPROX_COUNT_DISTINCT(x) FROM UNNEST([0, 1, 1, 2, 3, 5]) as x`,
@@ -1592,6 +1915,74 @@ FROM (
 				{"Suzy Slane", "03:06:24", "F35-39", "02:54:11", "03:01:17"},
 			},
 		},
+		{
+			name: `last_value ignore nulls with trailing nulls`,
+			query: `
+WITH Items AS (
+  SELECT 1 AS id, 'a' AS x UNION ALL
+  SELECT 2, 'b' UNION ALL
+  SELECT 3, NULL UNION ALL
+  SELECT 4, NULL
+)
+SELECT
+  id,
+  LAST_VALUE(x IGNORE NULLS) OVER (ORDER BY id ROWS BETWEEN UNBOUNDED PRECEDING AND UNBOUNDED FOLLOWING),
+  LAST_VALUE(x RESPECT NULLS) OVER (ORDER BY id ROWS BETWEEN UNBOUNDED PRECEDING AND UNBOUNDED FOLLOWING),
+  LAST_VALUE(x) OVER (ORDER BY id ROWS BETWEEN UNBOUNDED PRECEDING AND UNBOUNDED FOLLOWING),
+  LAST_VALUE(x IGNORE NULLS) OVER (ORDER BY id ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW)
+FROM Items ORDER BY id`,
+			expectedRows: [][]interface{}{
+				{int64(1), "b", nil, nil, "a"},
+				{int64(2), "b", nil, nil, "b"},
+				{int64(3), "b", nil, nil, "b"},
+				{int64(4), "b", nil, nil, "b"},
+			},
+		},
+		{
+			name: `first_value and nth_value ignore nulls with leading nulls`,
+			query: `
+WITH Items AS (
+  SELECT 1 AS id, NULL AS x UNION ALL
+  SELECT 2, 'b' UNION ALL
+  SELECT 3, 'c'
+)
+SELECT
+  id,
+  FIRST_VALUE(x IGNORE NULLS) OVER (ORDER BY id ROWS BETWEEN UNBOUNDED PRECEDING AND UNBOUNDED FOLLOWING),
+  FIRST_VALUE(x) OVER (ORDER BY id ROWS BETWEEN UNBOUNDED PRECEDING AND UNBOUNDED FOLLOWING),
+  NTH_VALUE(x, 2 IGNORE NULLS) OVER (ORDER BY id ROWS BETWEEN UNBOUNDED PRECEDING AND UNBOUNDED FOLLOWING),
+  NTH_VALUE(x, 2) OVER (ORDER BY id ROWS BETWEEN UNBOUNDED PRECEDING AND UNBOUNDED FOLLOWING)
+FROM Items ORDER BY id`,
+			expectedRows: [][]interface{}{
+				{int64(1), "b", nil, "c", "b"},
+				{int64(2), "b", nil, "c", "b"},
+				{int64(3), "b", nil, "c", "b"},
+			},
+		},
+		{
+			name: "navigation functions over empty frame",
+			query: `
+WITH Items AS (
+  SELECT 1 AS id, 'a' AS x UNION ALL
+  SELECT 2, 'b' UNION ALL
+  SELECT 3, 'c' UNION ALL
+  SELECT 10, 'd'
+)
+SELECT
+  id,
+  FIRST_VALUE(x) OVER (ORDER BY id RANGE BETWEEN 1 FOLLOWING AND 2 FOLLOWING),
+  LAST_VALUE(x) OVER (ORDER BY id RANGE BETWEEN 1 FOLLOWING AND 2 FOLLOWING),
+  NTH_VALUE(x, 2) OVER (ORDER BY id RANGE BETWEEN 1 FOLLOWING AND 2 FOLLOWING),
+  COUNT(*) OVER (ORDER BY id RANGE BETWEEN 1 FOLLOWING AND 2 FOLLOWING),
+  FIRST_VALUE(x) OVER (ORDER BY id ROWS BETWEEN 2 PRECEDING AND 1 PRECEDING)
+FROM Items ORDER BY id`,
+			expectedRows: [][]interface{}{
+				{int64(1), "b", "c", "c", int64(2), nil},
+				{int64(2), "c", "c", nil, int64(1), "a"},
+				{int64(3), nil, nil, nil, int64(0), "a"},
+				{int64(10), nil, nil, nil, int64(0), "b"},
+			},
+		},
 		{
 			name: `lead`,
 			query: `
@@ -2256,6 +2647,21 @@ FROM finishers`,
 				{int64(1)}, {int64(0)}, {int64(-1)},
 			},
 		},
+		{
+			name:         "abs",
+			query:        `SELECT ABS(-25), ABS(-9223372036854775807), ABS(-1.5), ABS(NUMERIC '-1.5')`,
+			expectedRows: [][]interface{}{{int64(25), int64(9223372036854775807), float64(1.5), "1.5"}},
+		},
+		{
+			name:        "abs with int64 overflow",
+			query:       `SELECT ABS(-9223372036854775808)`,
+			expectedErr: "ABS: int64 overflow: -9223372036854775808",
+		},
+		{
+			name:         "mod",
+			query:        `SELECT MOD(7, 3), MOD(-7, 3), MOD(7, -3), MOD(9223372036854775807, 10), MOD(NUMERIC '7.5', 2), MOD(NUMERIC '-7.5', 2)`,
+			expectedRows: [][]interface{}{{int64(1), int64(-1), int64(1), int64(7), "1.5", "-1.5"}},
+		},
 
 		{
 			name: "bit_count",
@@ -2381,6 +2787,48 @@ INNER JOIN unnest(['lettuce']) in_stock_items ON in_stock_items = item;`,
 				},
 			},
 		},
+		{
+			name:  "select distinct as struct",
+			query: `SELECT ARRAY (SELECT DISTINCT AS STRUCT a, b FROM UNNEST([STRUCT(1 AS a, 'x' AS b), (1, 'x'), (1, 'x')])) AS new_array`,
+			expectedRows: [][]interface{}{
+				{
+					[]interface{}{
+						[]map[string]interface{}{
+							{"a": float64(1)},
+							{"b": "x"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "select distinct as struct with multiple pairs",
+			query: `
+SELECT ARRAY (
+  SELECT AS STRUCT a, b FROM (
+    SELECT DISTINCT AS STRUCT a, b FROM UNNEST([STRUCT(1 AS a, 'x' AS b), (2, 'y'), (1, 'x'), (1, 'y'), (2, 'y')])
+  ) ORDER BY a, b
+) AS new_array`,
+			expectedRows: [][]interface{}{
+				{
+					[]interface{}{
+						[]map[string]interface{}{{"a": float64(1)}, {"b": "x"}},
+						[]map[string]interface{}{{"a": float64(1)}, {"b": "y"}},
+						[]map[string]interface{}{{"a": float64(2)}, {"b": "y"}},
+					},
+				},
+			},
+		},
+		{
+			name:         "select struct fields with dot star",
+			query:        `SELECT s.* FROM (SELECT STRUCT(1 AS a, 2 AS b) s)`,
+			expectedRows: [][]interface{}{{int64(1), int64(2)}},
+		},
+		{
+			name:         "select struct fields with dot star and other columns",
+			query:        `SELECT id, s.* FROM (SELECT 'x' AS id, STRUCT(1 AS a, 'y' AS b) s UNION ALL SELECT 'z', STRUCT(2, 'w')) ORDER BY id`,
+			expectedRows: [][]interface{}{{"x", int64(1), "y"}, {"z", int64(2), "w"}},
+		},
 		{
 			name: "array function with other column",
 			query: `
@@ -2406,11 +2854,21 @@ SELECT ARRAY (
 				},
 			},
 		},
+		{
+			name:         "array_concat function with null",
+			query:        `SELECT ARRAY_CONCAT([1, 2], NULL)`,
+			expectedRows: [][]interface{}{{nil}},
+		},
 		{
 			name:         "array_length function",
 			query:        `SELECT ARRAY_LENGTH([1, 2, 3, 4]) as length`,
 			expectedRows: [][]interface{}{{int64(4)}},
 		},
+		{
+			name:         "array_length function with empty and null array",
+			query:        `SELECT ARRAY_LENGTH(ARRAY<INT64>[]), ARRAY_LENGTH(CAST(NULL AS ARRAY<INT64>))`,
+			expectedRows: [][]interface{}{{int64(0), nil}},
+		},
 		{
 			name: "array_to_string function",
 			query: `
@@ -2526,6 +2984,37 @@ SELECT ARRAY_TO_STRING(list, '--', 'MISSING') AS text FROM items`,
 				{[]interface{}{"2016-01-01", "2016-03-01", "2016-05-01", "2016-07-01", "2016-09-01", "2016-11-01"}},
 			},
 		},
+		{
+			name:  "generate_date_array function with month at the end of month",
+			query: `SELECT GENERATE_DATE_ARRAY('2016-01-31', '2016-05-31', INTERVAL 1 MONTH) AS example`,
+			expectedRows: [][]interface{}{
+				{[]interface{}{"2016-01-31", "2016-02-29", "2016-03-31", "2016-04-30", "2016-05-31"}},
+			},
+		},
+		{
+			name:  "generate_date_array function with week",
+			query: `SELECT GENERATE_DATE_ARRAY('2016-10-05', '2016-10-31', INTERVAL 1 WEEK) AS example`,
+			expectedRows: [][]interface{}{
+				{[]interface{}{"2016-10-05", "2016-10-12", "2016-10-19", "2016-10-26"}},
+			},
+		},
+		{
+			name:  "generate_date_array function with quarter and year",
+			query: `SELECT GENERATE_DATE_ARRAY('2016-01-15', '2016-12-31', INTERVAL 1 QUARTER), GENERATE_DATE_ARRAY('2016-02-29', '2020-03-01', INTERVAL 2 YEAR)`,
+			expectedRows: [][]interface{}{
+				{
+					[]interface{}{"2016-01-15", "2016-04-15", "2016-07-15", "2016-10-15"},
+					[]interface{}{"2016-02-29", "2018-02-28", "2020-02-29"},
+				},
+			},
+		},
+		{
+			name:  "generate_date_array function with descending month",
+			query: `SELECT GENERATE_DATE_ARRAY('2016-05-31', '2016-01-01', INTERVAL -1 MONTH) AS example`,
+			expectedRows: [][]interface{}{
+				{[]interface{}{"2016-05-31", "2016-04-30", "2016-03-31", "2016-02-29", "2016-01-31"}},
+			},
+		},
 		{
 			name: "generate_date_array function with variable",
 			query: `
@@ -2678,6 +3167,118 @@ WITH example AS (
 				{[]interface{}{}},
 			},
 		},
+		{
+			name: "array_reverse function with null elements",
+			query: `
+SELECT
+  ARRAY_REVERSE(['a', NULL, 'b']),
+  ARRAY_REVERSE([DATE '2022-01-01', NULL]),
+  ARRAY_REVERSE(CAST(NULL AS ARRAY<INT64>))`,
+			expectedRows: [][]interface{}{
+				{[]interface{}{"b", nil, "a"}, []interface{}{nil, "2022-01-01"}, nil},
+			},
+		},
+		{
+			name: "array_first and array_last",
+			query: `
+SELECT
+  ARRAY_FIRST([1, 2, 3]),
+  ARRAY_LAST([1, 2, 3]),
+  ARRAY_FIRST(['a', 'b']),
+  ARRAY_LAST([DATE '2022-01-01', DATE '2022-12-31']),
+  ARRAY_FIRST([STRUCT(1 AS a)]).a,
+  ARRAY_LAST(CAST(NULL AS ARRAY<INT64>))`,
+			expectedRows: [][]interface{}{{int64(1), int64(3), "a", "2022-12-31", int64(1), nil}},
+		},
+		{
+			name:        "array_first with empty array",
+			query:       `SELECT ARRAY_FIRST(ARRAY<INT64>[])`,
+			expectedErr: "ARRAY_FIRST: cannot get the first element of an empty array",
+		},
+		{
+			name:        "array_last with empty array",
+			query:       `SELECT ARRAY_LAST(ARRAY<INT64>[])`,
+			expectedErr: "ARRAY_LAST: cannot get the last element of an empty array",
+		},
+		{
+			name: "array_is_distinct",
+			query: `
+SELECT
+  ARRAY_IS_DISTINCT([1, 2, 3]),
+  ARRAY_IS_DISTINCT([1, 1, 1]),
+  ARRAY_IS_DISTINCT(['a', NULL, 'b']),
+  ARRAY_IS_DISTINCT(['a', NULL, NULL]),
+  ARRAY_IS_DISTINCT(ARRAY<INT64>[]),
+  ARRAY_IS_DISTINCT(CAST(NULL AS ARRAY<INT64>))`,
+			expectedRows: [][]interface{}{{true, false, true, false, true, nil}},
+		},
+		{
+			name: "array_includes",
+			query: `
+SELECT
+  ARRAY_INCLUDES([1, 2, 3], 2),
+  ARRAY_INCLUDES([1, NULL, 3], 4),
+  ARRAY_INCLUDES(['a', 'b'], 'b'),
+  ARRAY_INCLUDES([1, 2], NULL),
+  ARRAY_INCLUDES(CAST(NULL AS ARRAY<INT64>), 1)`,
+			expectedRows: [][]interface{}{{true, false, true, nil, nil}},
+		},
+		{
+			name: "array_includes_any and array_includes_all",
+			query: `
+SELECT
+  ARRAY_INCLUDES_ANY([1, 2, 3], [3, NULL, 5]),
+  ARRAY_INCLUDES_ANY([1, 2, 3], [4, 5]),
+  ARRAY_INCLUDES_ANY([1, 2, 3], ARRAY<INT64>[]),
+  ARRAY_INCLUDES_ANY([1, 2, 3], CAST(NULL AS ARRAY<INT64>)),
+  ARRAY_INCLUDES_ALL([1, 2, 3, 4, 5], [3, 4, 5]),
+  ARRAY_INCLUDES_ALL([1, 2, 3, 4, 5], [4, 5, 6]),
+  ARRAY_INCLUDES_ALL([1, 2, 3], ARRAY<INT64>[]),
+  ARRAY_INCLUDES_ALL(CAST(NULL AS ARRAY<INT64>), [1])`,
+			expectedRows: [][]interface{}{{true, false, false, nil, true, false, true, nil}},
+		},
+		{
+			name: "array_min and array_max",
+			query: `
+SELECT
+  ARRAY_MIN([8, 37, NULL, 55, 4]),
+  ARRAY_MAX([8, 37, NULL, 55, 4]),
+  ARRAY_MIN(['b', 'a', 'c']),
+  ARRAY_MAX([DATE '2022-01-01', DATE '2022-12-31']),
+  ARRAY_MIN(ARRAY<INT64>[]),
+  ARRAY_MAX([CAST(NULL AS INT64)]),
+  ARRAY_MIN(CAST(NULL AS ARRAY<INT64>))`,
+			expectedRows: [][]interface{}{{int64(4), int64(55), "a", "2022-12-31", nil, nil, nil}},
+		},
+		{
+			name: "array_sum and array_avg",
+			query: `
+SELECT
+  ARRAY_SUM([1, 2, NULL, 3]),
+  ARRAY_SUM([1.5, 2.5]),
+  CAST(ARRAY_SUM([NUMERIC '1.1', NUMERIC '2.2']) AS STRING),
+  ARRAY_AVG([1, 2, NULL, 4]),
+  ARRAY_AVG([1.5, 2.5]),
+  CAST(ARRAY_AVG([NUMERIC '1', NUMERIC '2']) AS STRING),
+  ARRAY_SUM(ARRAY<INT64>[]),
+  ARRAY_AVG([CAST(NULL AS FLOAT64)])`,
+			expectedRows: [][]interface{}{{int64(6), float64(4), "3.3", float64(7) / 3, float64(2), "1.5", nil, nil}},
+		},
+		{
+			name:        "array_sum with int64 overflow",
+			query:       `SELECT ARRAY_SUM([9223372036854775807, 1])`,
+			expectedErr: "ARRAY_SUM: int64 overflow: 9223372036854775807 + 1",
+		},
+		{
+			name:         "array_avg with large int64 values",
+			query:        `SELECT ARRAY_AVG([9223372036854775807, 9223372036854775807])`,
+			expectedRows: [][]interface{}{{float64(9223372036854775807)}},
+		},
+		{
+			name:        "array_sum with non-numeric element type",
+			query:       `SELECT ARRAY_SUM(['a', 'b'])`,
+			expectedErr: "No matching signature for function ARRAY_SUM",
+		},
 		{
 			name: "group by",
 			query: `
@@ -2756,6 +3357,29 @@ ORDER BY sku, day`,
 				{int64(789), int64(3), float64(1.99)},
 			},
 		},
+		{
+			name: "group by rollup with null grouping column values",
+			query: `
+WITH Items AS (
+  SELECT CAST(NULL AS STRING) AS category, 1 AS value UNION ALL
+  SELECT 'a', 2 UNION ALL
+  SELECT 'a', 3 UNION ALL
+  SELECT 'b', 4
+)
+SELECT
+  category,
+  SUM(value) AS total,
+  COUNT(*) AS num
+FROM Items
+GROUP BY ROLLUP(category)
+ORDER BY category, total`,
+			expectedRows: [][]interface{}{
+				{nil, int64(1), int64(1)},
+				{nil, int64(10), int64(4)},
+				{"a", int64(5), int64(2)},
+				{"b", int64(4), int64(1)},
+			},
+		},
 		{
 			name: "group by having",
 			query: `
@@ -3024,11 +3648,47 @@ SELECT item FROM Produce WHERE Produce.category = 'vegetable' QUALIFY RANK() OVE
 			expectedRows: [][]interface{}{{[]any{int64(800), int64(-900), int64(100), int64(0), int64(0)}}},
 		},
 
-		// hash functions
+		// math functions
 		{
-			name: "farm_fingerprint",
+			name: "cbrt",
 			query: `
-WITH example AS (
+SELECT
+  CBRT(27),
+  CBRT(-27),
+  CBRT(0.125),
+  CBRT(NULL),
+  IS_INF(CBRT(IEEE_DIVIDE(1, 0))),
+  CBRT(IEEE_DIVIDE(-1, 0)) < 0`,
+			expectedRows: [][]interface{}{{float64(3), float64(-3), float64(0.5), nil, true, true}},
+		},
+		{
+			name: "cot coth csc csch sec sech",
+			query: `
+SELECT
+  COT(1), COTH(1), CSC(1), CSCH(1), SEC(0), SECH(0),
+  IS_NAN(COT(IEEE_DIVIDE(1, 0))), COTH(IEEE_DIVIDE(1, 0)), COT(NULL)`,
+			expectedRows: [][]interface{}{{
+				float64(0.6420926159343306), float64(1.3130352854993315), float64(1.1883951057781212),
+				float64(0.8509181282393216), float64(1), float64(1),
+				true, float64(1), nil,
+			}},
+		},
+		{
+			name:        "cot with zero",
+			query:       `SELECT COT(0)`,
+			expectedErr: "COT: zero divided",
+		},
+		{
+			name:        "csch with zero",
+			query:       `SELECT CSCH(0)`,
+			expectedErr: "CSCH: zero divided",
+		},
+
+		// hash functions
+		{
+			name: "farm_fingerprint",
+			query: `
+WITH example AS (
   SELECT 1 AS x, "foo" AS y, true AS z UNION ALL
   SELECT 2 AS x, "apple" AS y, false AS z UNION ALL
   SELECT 3 AS x, "" AS y, true AS z
@@ -3039,6 +3699,22 @@ WITH example AS (
 				{int64(3), "", true, int64(-4880158226897771312)},
 			},
 		},
+		{
+			name: "farm_fingerprint buckets",
+			query: `
+WITH example AS (
+  SELECT 1 AS x, "foo" AS y, true AS z UNION ALL
+  SELECT 2 AS x, "apple" AS y, false AS z UNION ALL
+  SELECT 3 AS x, "" AS y, true AS z
+) SELECT x, MOD(ABS(fp), 10), MOD(fp, 10) FROM (
+  SELECT x, FARM_FINGERPRINT(CONCAT(CAST(x AS STRING), y, CAST(z AS STRING))) AS fp FROM example
+) ORDER BY x`,
+			expectedRows: [][]interface{}{
+				{int64(1), int64(1), int64(-1)},
+				{int64(2), int64(9), int64(9)},
+				{int64(3), int64(2), int64(-2)},
+			},
+		},
 		{
 			name:         "md5",
 			query:        `SELECT MD5("Hello World")`,
@@ -3126,6 +3802,11 @@ SELECT characters, CHARACTER_LENGTH(characters) FROM example`,
 			query:        `SELECT CONCAT('T.P.', ' ', 'Bar'), CONCAT('Summer', ' ', 1923), CONCAT("abc"), CONCAT(1), CONCAT('A', NULL, 'C'), CONCAT(NULL)`,
 			expectedRows: [][]interface{}{{"T.P. Bar", "Summer 1923", "abc", "1", nil, nil}},
 		},
+		{
+			name:         "concat bytes",
+			query:        `SELECT CONCAT(b'\x00', b'\x01'), FORMAT('%T', CONCAT(b'\xab', b'\xcd\xef')), CONCAT(b'a', NULL)`,
+			expectedRows: [][]interface{}{{"AAE=", `b"\xab\xcd\xef"`, nil}},
+		},
 		// TODO: currently unsupported CONTAINS_SUBSTR function because ZetaSQL library doesn't support it.
 		// {
 		//	name:         "contains_substr true",
@@ -3246,6 +3927,26 @@ SELECT characters, CHARACTER_LENGTH(characters) FROM example`,
 			query:        `SELECT FORMAT('%f %E', 1.1, 2.2)`,
 			expectedRows: [][]interface{}{{"1.100000 2.200000E+00"}},
 		},
+		{
+			name:         "format %e with precision",
+			query:        `SELECT FORMAT('%e|%.2e|%E|%.0e|%10.3e', 12345.678, 12345.678, 0.000123, 12345.678, 1234.5)`,
+			expectedRows: [][]interface{}{{"1.234568e+04|1.23e+04|1.230000E-04|1e+04| 1.234e+03"}},
+		},
+		{
+			name:         "format %g",
+			query:        `SELECT FORMAT('%g|%g|%g|%g|%.3g|%G|%.0g', 12345.678, 0.0001234, 1234567.0, 100.0, 3.14159, 0.00001234, 2.5)`,
+			expectedRows: [][]interface{}{{"12345.7|0.0001234|1.23457e+06|100|3.14|1.234E-05|2"}},
+		},
+		{
+			name:         "format float with precision from argument",
+			query:        `SELECT FORMAT('%.*f|%.*g', 2, 3.14159, 3, 3.14159)`,
+			expectedRows: [][]interface{}{{"3.14|3.14"}},
+		},
+		{
+			name:         "format infinity and nan",
+			query:        `SELECT FORMAT('%f|%e|%G|%g', CAST('inf' AS FLOAT64), CAST('-inf' AS FLOAT64), CAST('inf' AS FLOAT64), CAST('nan' AS FLOAT64))`,
+			expectedRows: [][]interface{}{{"inf|-inf|INF|nan"}},
+		},
 		{
 			name:         "format date with %t",
 			query:        `SELECT FORMAT('%t', date '2015-09-01')`,
@@ -3256,6 +3957,39 @@ SELECT characters, CHARACTER_LENGTH(characters) FROM example`,
 			query:        `SELECT FORMAT('%t', timestamp '2015-09-01 12:34:56 America/Los_Angeles')`,
 			expectedRows: [][]interface{}{{"2015-09-01 19:34:56+00"}},
 		},
+		{
+			name:         "format bytes with %T",
+			query:        `SELECT FORMAT('%T', b'\x00\x01\n\tab\\c\xff'), FORMAT('%T', b'say "hi"'), FORMAT('%T', b'it\'s "x"')`,
+			expectedRows: [][]interface{}{{`b"\x00\x01\n\tab\\c\xff"`, `b'say "hi"'`, `b"it's \"x\""`}},
+		},
+		{
+			name:         "format struct with %t and %T",
+			query:        `SELECT FORMAT('%t', STRUCT(1 AS a, 'x' AS b, [1.5, 2.0] AS c)), FORMAT('%T', STRUCT(1 AS a, 'x' AS b, [1.5, 2.0] AS c))`,
+			expectedRows: [][]interface{}{{"(1, x, [1.5, 2.0])", `(1, "x", [1.5, 2.0])`}},
+		},
+		{
+			name:         "format array with %t and %T",
+			query:        `SELECT FORMAT('%t', [DATE '2020-01-01', NULL]), FORMAT('%T', [DATE '2020-01-01', NULL]), FORMAT('%T', [STRUCT('a' AS x, b'b' AS y)])`,
+			expectedRows: [][]interface{}{{"[2020-01-01, NULL]", `[DATE "2020-01-01", NULL]`, `[("a", b"b")]`}},
+		},
+		{
+			name:         "format null with %t and %T",
+			query:        `SELECT FORMAT('%t', CAST(NULL AS INT64)), FORMAT('%T', CAST(NULL AS STRING)), FORMAT('%d', CAST(NULL AS INT64))`,
+			expectedRows: [][]interface{}{{"NULL", "NULL", nil}},
+		},
+		{
+			name: "format literals with %T",
+			query: `SELECT FORMAT('%T', NUMERIC '1.50'), FORMAT('%T', 3.0), FORMAT('%T', CAST('inf' AS FLOAT64)),
+ FORMAT('%T', TIMESTAMP '2020-01-01 00:00:00.123+00'), FORMAT('%T', DATETIME '2020-01-01 12:34:56'), FORMAT('%T', INTERVAL 1 DAY)`,
+			expectedRows: [][]interface{}{{
+				`NUMERIC "1.5"`,
+				"3.0",
+				`CAST("inf" AS FLOAT64)`,
+				`TIMESTAMP "2020-01-01 00:00:00.123+00"`,
+				`DATETIME "2020-01-01 12:34:56"`,
+				`INTERVAL "0-0 1 0:0:0" YEAR TO SECOND`,
+			}},
+		},
 		// This fails in ZetaSQL base code.
 		// {
 		// 	name:         "format null",
@@ -3751,8 +4485,8 @@ WITH examples AS (
 				{"abc", int64(5), `"abc  "`},
 				{"abc", int64(2), `"ab"`},
 				{"例子", int64(4), `"例子  "`},
-				{nil, int64(2), nil},
-				{"abc", nil, nil},
+				{nil, int64(2), "NULL"},
+				{"abc", nil, "NULL"},
 			},
 		},
 		{
@@ -3765,7 +4499,7 @@ WITH examples AS (
 			expectedRows: [][]interface{}{
 				{"abc", int64(8), "def", `"abcdefde"`},
 				{"abc", int64(5), "-", `"abc--"`},
-				{"abc", int64(5), nil, nil},
+				{"abc", int64(5), nil, "NULL"},
 				{"例子", int64(5), "中文", `"例子中文中"`},
 			},
 		},
@@ -4006,6 +4740,11 @@ WITH example AS (
 			query:        `SELECT DATE_ADD('2023-01-01', INTERVAL 1 QUARTER), DATE_ADD('2023-11-30', INTERVAL 1 QUARTER)`,
 			expectedRows: [][]interface{}{{"2023-04-01", "2024-02-29"}},
 		},
+		{
+			name:         "date_add and date_sub with multiple quarters",
+			query:        `SELECT DATE_ADD('2023-01-31', INTERVAL 2 QUARTER), DATE_SUB('2023-08-31', INTERVAL 2 QUARTER)`,
+			expectedRows: [][]interface{}{{"2023-07-31", "2023-02-28"}},
+		},
 		{
 			name:         "date_trunc with quarter",
 			query:        `SELECT DATE_TRUNC(DATE "2017-01-05", QUARTER), DATE_TRUNC(DATE "2017-02-05", QUARTER), DATE_TRUNC(DATE "2017-08-05", QUARTER), DATE_TRUNC(DATE "2017-11-05", QUARTER), DATE_TRUNC(DATE "2017-12-31", QUARTER)`,
@@ -4291,6 +5030,26 @@ SELECT date, EXTRACT(ISOYEAR FROM date), EXTRACT(YEAR FROM date), EXTRACT(MONTH
 			query:        `SELECT DATE_FROM_UNIX_DATE(14238) AS date_from_epoch`,
 			expectedRows: [][]interface{}{{"2008-12-25"}},
 		},
+		{
+			name: "date_bucket",
+			query: `
+SELECT DATE_BUCKET(d, INTERVAL 2 DAY)
+FROM UNNEST([DATE '1949-12-30', DATE '1949-12-31', DATE '1950-01-01', DATE '1950-01-02', DATE '1950-01-03']) AS d
+ORDER BY d`,
+			expectedRows: [][]interface{}{
+				{"1949-12-30"}, {"1949-12-30"}, {"1950-01-01"}, {"1950-01-01"}, {"1950-01-03"},
+			},
+		},
+		{
+			name:         "date_bucket with origin and month width",
+			query:        `SELECT DATE_BUCKET(DATE '2000-12-22', INTERVAL 7 DAY, DATE '2000-12-24'), DATE_BUCKET(DATE '2024-05-15', INTERVAL 3 MONTH), DATE_BUCKET(DATE '2024-05-15', INTERVAL 1 YEAR, DATE '2000-06-30'), DATE_BUCKET(NULL, INTERVAL 1 DAY)`,
+			expectedRows: [][]interface{}{{"2000-12-17", "2024-04-01", "2023-06-30", nil}},
+		},
+		{
+			name:        "date_bucket with time parts",
+			query:       `SELECT DATE_BUCKET(DATE '2024-05-15', INTERVAL 1 HOUR)`,
+			expectedErr: "DATE_BUCKET: bucket width must not have time parts",
+		},
 		{
 			name:         "date_trunc with day",
 			query:        `SELECT DATE_TRUNC(DATE "2008-12-25", DAY)`,
@@ -4331,6 +5090,23 @@ SELECT date, EXTRACT(ISOYEAR FROM date), EXTRACT(YEAR FROM date), EXTRACT(MONTH
 			query:        `SELECT FORMAT_DATE("%b %Y", DATE "2008-12-25")`,
 			expectedRows: [][]interface{}{{"Dec 2008"}},
 		},
+		{
+			name:  "format_date with weekday names",
+			query: `SELECT FORMAT_DATE("%A %a", d) FROM UNNEST(GENERATE_DATE_ARRAY("2023-01-01", "2023-01-07")) AS d ORDER BY d`,
+			expectedRows: [][]interface{}{
+				{"Sunday Sun"}, {"Monday Mon"}, {"Tuesday Tue"}, {"Wednesday Wed"},
+				{"Thursday Thu"}, {"Friday Fri"}, {"Saturday Sat"},
+			},
+		},
+		{
+			name:  "format_date with month names",
+			query: `SELECT FORMAT_DATE("%B %b %h", d) FROM UNNEST(GENERATE_DATE_ARRAY("2023-01-31", "2023-12-31", INTERVAL 1 MONTH)) AS d ORDER BY d`,
+			expectedRows: [][]interface{}{
+				{"January Jan Jan"}, {"February Feb Feb"}, {"March Mar Mar"}, {"April Apr Apr"},
+				{"May May May"}, {"June Jun Jun"}, {"July Jul Jul"}, {"August Aug Aug"},
+				{"September Sep Sep"}, {"October Oct Oct"}, {"November Nov Nov"}, {"December Dec Dec"},
+			},
+		},
 		{
 			name:         "format_date with %E4Y",
 			query:        `SELECT FORMAT_DATE("%E4Y", DATE "2008-12-25")`,
@@ -4423,6 +5199,36 @@ SELECT date, EXTRACT(ISOYEAR FROM date), EXTRACT(YEAR FROM date), EXTRACT(MONTH
 			query:        `SELECT PARSE_DATE('%e', ' 20');`,
 			expectedRows: [][]interface{}{{"1970-01-20"}},
 		},
+		{
+			name:         "parse date with year and month only",
+			query:        `SELECT PARSE_DATE("%Y-%m", "2008-12"), PARSE_DATE("%Y", "2008"), PARSE_DATE("%b %Y", "Feb 2024")`,
+			expectedRows: [][]interface{}{{"2008-12-01", "2008-01-01", "2024-02-01"}},
+		},
+		{
+			name:         "parse datetime with year and month only",
+			query:        `SELECT PARSE_DATETIME("%Y-%m", "2008-12"), PARSE_DATETIME("%Y-%m-%d %H", "2008-12-25 07")`,
+			expectedRows: [][]interface{}{{"2008-12-01T00:00:00", "2008-12-25T07:00:00"}},
+		},
+		{
+			name:        "parse date with trailing text",
+			query:       `SELECT PARSE_DATE("%Y-%m-%d", "2008-12-25xyz")`,
+			expectedErr: "error parsing [2008-12-25xyz] with format [%Y-%m-%d]: found unparsed text [xyz]",
+		},
+		{
+			name:        "parse datetime with trailing text",
+			query:       `SELECT PARSE_DATETIME("%Y-%m-%d", "2008-12-25 10:00:00")`,
+			expectedErr: "error parsing [2008-12-25 10:00:00] with format [%Y-%m-%d]: found unparsed text [ 10:00:00]",
+		},
+		{
+			name:        "parse date with mismatched separator",
+			query:       `SELECT PARSE_DATE("%Y-%m-%d", "2008/12/25")`,
+			expectedErr: "error parsing [2008/12/25] with format [%Y-%m-%d]: mismatch between format character [-] and string character [/]",
+		},
+		{
+			name:        "parse date with missing separator",
+			query:       `SELECT PARSE_DATE("%Y-%m-", "2008-12")`,
+			expectedErr: "error parsing [2008-12] with format [%Y-%m-]: mismatch between format character [-] and string character []",
+		},
 		{
 			name:        "parse date with %F no day field",
 			query:       `SELECT PARSE_DATE("%F", "2008-01") AS parsed`,
@@ -4468,6 +5274,22 @@ SELECT date, EXTRACT(ISOYEAR FROM date), EXTRACT(YEAR FROM date), EXTRACT(MONTH
 			query:        `SELECT UNIX_DATE(DATE "2008-12-25") AS days_from_epoch`,
 			expectedRows: [][]interface{}{{int64(14238)}},
 		},
+		{
+			name: "unix_date and date_from_unix_date round trip",
+			query: `
+SELECT
+  UNIX_DATE(DATE_FROM_UNIX_DATE(-719162)), UNIX_DATE(DATE_FROM_UNIX_DATE(2932896)),
+  DATE_FROM_UNIX_DATE(UNIX_DATE(DATE "1969-12-31")), DATE_FROM_UNIX_DATE(UNIX_DATE(DATE "9999-12-31")),
+  UNIX_DATE(DATE "0001-01-01")`,
+			expectedRows: [][]interface{}{
+				{int64(-719162), int64(2932896), "1969-12-31", "9999-12-31", int64(-719162)},
+			},
+		},
+		{
+			name:        "date_from_unix_date out of range",
+			query:       `SELECT DATE_FROM_UNIX_DATE(2932897)`,
+			expectedErr: "DATE_FROM_UNIX_DATE: date value 2932897 is out of range",
+		},
 
 		// datetime functions
 		{
@@ -4528,6 +5350,21 @@ SELECT date, EXTRACT(ISOYEAR FROM date), EXTRACT(YEAR FROM date), EXTRACT(MONTH
 			query:        `SELECT DATETIME_DIFF('2017-12-18', '2017-12-17', WEEK), DATETIME_DIFF('2017-12-18', '2017-12-17', WEEK(MONDAY)), DATETIME_DIFF('2017-12-18', '2017-12-17', ISOWEEK)`,
 			expectedRows: [][]interface{}{{int64(0), int64(1), int64(1)}},
 		},
+		{
+			name:         "datetime_bucket",
+			query:        `SELECT DATETIME_BUCKET(DATETIME '2024-05-15 10:37:12', INTERVAL 15 MINUTE), DATETIME_BUCKET(DATETIME '2024-05-15 10:37:12', INTERVAL 15 MINUTE, DATETIME '2024-05-15 00:05:00'), DATETIME_BUCKET(DATETIME '2024-05-31 10:00:00', INTERVAL 1 MONTH, DATETIME '2024-01-31 12:00:00')`,
+			expectedRows: [][]interface{}{{"2024-05-15T10:30:00", "2024-05-15T10:35:00", "2024-04-30T12:00:00"}},
+		},
+		{
+			name:        "datetime_bucket with zero width",
+			query:       `SELECT DATETIME_BUCKET(DATETIME '2024-05-15 10:37:12', INTERVAL 0 DAY)`,
+			expectedErr: "DATETIME_BUCKET: bucket width must be positive",
+		},
+		{
+			name:        "datetime_bucket with mixed parts",
+			query:       `SELECT DATETIME_BUCKET(DATETIME '2024-05-15 10:37:12', MAKE_INTERVAL(month => 1, day => 1))`,
+			expectedErr: "DATETIME_BUCKET: bucket width must not mix Y-M parts and D H:M:S parts",
+		},
 		{
 			name:         "datetime_trunc with day",
 			query:        `SELECT DATETIME_TRUNC(DATETIME "2008-12-25 15:30:00", DAY)`,
@@ -4769,6 +5606,16 @@ SELECT date, EXTRACT(ISOYEAR FROM date), EXTRACT(YEAR FROM date), EXTRACT(MONTH
 			query:        `SELECT TIMESTAMP_DIFF(TIMESTAMP "2010-07-07 10:20:00+00", TIMESTAMP "2008-12-25 15:30:00+00", HOUR)`,
 			expectedRows: [][]interface{}{{int64(13410)}},
 		},
+		{
+			name:         "timestamp_bucket",
+			query:        `SELECT TIMESTAMP_BUCKET(TIMESTAMP '2024-05-15 10:37:12+00', INTERVAL 12 HOUR), TIMESTAMP_BUCKET(TIMESTAMP '1949-12-31 23:59:59.5+00', INTERVAL 1 SECOND)`,
+			expectedRows: [][]interface{}{{createTimestampFormatFromString("2024-05-15 00:00:00+00"), createTimestampFormatFromString("1949-12-31 23:59:59+00")}},
+		},
+		{
+			name:        "timestamp_bucket with month width",
+			query:       `SELECT TIMESTAMP_BUCKET(TIMESTAMP '2024-05-15 10:37:12+00', INTERVAL 1 MONTH)`,
+			expectedErr: "TIMESTAMP_BUCKET: bucket width must not have Y-M parts",
+		},
 		{
 			name:  "timestamp_trunc with day",
 			query: `SELECT TIMESTAMP_TRUNC(TIMESTAMP "2008-12-25 15:30:00+00", DAY, "UTC"), TIMESTAMP_TRUNC(TIMESTAMP "2008-12-25 15:30:00+00", DAY, "America/Los_Angeles")`,
@@ -4802,6 +5649,13 @@ SELECT date, EXTRACT(ISOYEAR FROM date), EXTRACT(YEAR FROM date), EXTRACT(MONTH
 			query:        `SELECT FORMAT_TIMESTAMP("%c", TIMESTAMP "2008-12-25 15:30:00+00", "UTC")`,
 			expectedRows: [][]interface{}{{"Thu Dec 25 15:30:00 2008"}},
 		},
+		{
+			name:  "format_timestamp with weekday and month names",
+			query: `SELECT FORMAT_TIMESTAMP("%A %a %B %b", TIMESTAMP "2023-12-31 20:00:00+00"), FORMAT_TIMESTAMP("%A %a %B %b", TIMESTAMP "2023-12-31 20:00:00+00", "Asia/Tokyo")`,
+			expectedRows: [][]interface{}{
+				{"Sunday Sun December Dec", "Monday Mon January Jan"},
+			},
+		},
 		{
 			name:         "format_timestamp with %b-%d-%Y",
 			query:        `SELECT FORMAT_TIMESTAMP("%b-%d-%Y", TIMESTAMP "2008-12-25 15:30:00+00")`,
@@ -4926,6 +5780,31 @@ SELECT date, EXTRACT(ISOYEAR FROM date), EXTRACT(YEAR FROM date), EXTRACT(MONTH
 			query:        `SELECT UNIX_MICROS(TIMESTAMP "2008-12-25 15:30:00+00")`,
 			expectedRows: [][]interface{}{{int64(1230219000000000)}},
 		},
+		{
+			name:         "unix_micros and timestamp_micros round trip",
+			query:        `SELECT UNIX_MICROS(TIMESTAMP_MICROS(1230219000123456)), TIMESTAMP_MICROS(UNIX_MICROS(TIMESTAMP "2008-12-25 15:30:00.123456+00"))`,
+			expectedRows: [][]interface{}{{int64(1230219000123456), createTimestampFormatFromString("2008-12-25 15:30:00.123456+00")}},
+		},
+		{
+			name:         "timestamp_seconds min and max",
+			query:        `SELECT UNIX_SECONDS(TIMESTAMP_SECONDS(-62135596800)), UNIX_SECONDS(TIMESTAMP_SECONDS(253402300799))`,
+			expectedRows: [][]interface{}{{int64(-62135596800), int64(253402300799)}},
+		},
+		{
+			name:        "timestamp_seconds out of range",
+			query:       `SELECT TIMESTAMP_SECONDS(253402300800)`,
+			expectedErr: "TIMESTAMP_SECONDS: timestamp value 253402300800 is out of range",
+		},
+		{
+			name:        "timestamp_millis out of range",
+			query:       `SELECT TIMESTAMP_MILLIS(-62135596800001)`,
+			expectedErr: "TIMESTAMP_MILLIS: timestamp value -62135596800001 is out of range",
+		},
+		{
+			name:        "timestamp_micros out of range",
+			query:       `SELECT TIMESTAMP_MICROS(253402300800000000)`,
+			expectedErr: "TIMESTAMP_MICROS: timestamp value 253402300800000000 is out of range",
+		},
 		{
 			name: "extract from timestamp",
 			query: `
@@ -4946,6 +5825,60 @@ FROM Input`,
 			query:        `SELECT DATE "2020-09-22" + val FROM UNNEST([INTERVAL 1 DAY,INTERVAL -1 DAY,INTERVAL 2 YEAR,CAST('1-2 3 18:1:55' AS INTERVAL)]) as val`,
 			expectedRows: [][]interface{}{{"2020-09-23T00:00:00"}, {"2020-09-21T00:00:00"}, {"2022-09-22T00:00:00"}, {"2021-11-25T18:01:55"}},
 		},
+		{
+			name: "negative interval operator",
+			query: `
+SELECT
+  DATE "2020-09-22" + INTERVAL -5 DAY,
+  DATETIME "2020-09-22 12:00:00" + INTERVAL -30 MINUTE,
+  DATETIME "2020-09-22 12:00:00" - INTERVAL -30 MINUTE,
+  DATETIME "2020-09-22 12:00:00" + CAST('1-2 -3 -18:1:55' AS INTERVAL)`,
+			expectedRows: [][]interface{}{
+				{"2020-09-17T00:00:00", "2020-09-22T11:30:00", "2020-09-22T12:30:00", "2021-11-18T17:58:05"},
+			},
+		},
+		{
+			name: "interval operator clamps to the end of month",
+			query: `
+SELECT
+  DATE "2023-01-31" + INTERVAL 1 MONTH,
+  DATE "2024-03-31" - INTERVAL 1 MONTH,
+  DATETIME "2024-02-29 10:00:00" + INTERVAL 1 YEAR,
+  DATETIME "2023-01-31 23:30:00" + CAST('0-1 1 1:0:0' AS INTERVAL),
+  TIMESTAMP "2023-05-31 12:00:00+00" - INTERVAL 3 MONTH`,
+			expectedRows: [][]interface{}{
+				{"2023-02-28T00:00:00", "2024-02-29T00:00:00", "2025-02-28T10:00:00", "2023-03-02T00:30:00", createTimestampFormatFromString("2023-02-28 12:00:00+00")},
+			},
+		},
+		{
+			name: "interval operator with interval column",
+			query: `
+SELECT INTERVAL 2 DAY + d, ts + i, ts - i FROM UNNEST([STRUCT(
+  DATE "2020-02-28" AS d,
+  TIMESTAMP "2020-01-31 00:00:00.5+00" AS ts,
+  MAKE_INTERVAL(month => 1, second => 1) AS i
+)])`,
+			expectedRows: [][]interface{}{
+				{
+					"2020-03-01T00:00:00",
+					createTimestampFormatFromString("2020-02-29 00:00:01.5+00"),
+					createTimestampFormatFromString("2019-12-30 23:59:59.5+00"),
+				},
+			},
+		},
+		{
+			name: "negative interval values",
+			query: `
+SELECT
+  INTERVAL -30 MINUTE,
+  INTERVAL -1 MONTH,
+  CAST('0-0 0 -0:30:0' AS INTERVAL),
+  CAST('1-2 -3 -18:1:55' AS INTERVAL),
+  JUSTIFY_INTERVAL(INTERVAL '-1 25:0:0' DAY TO SECOND)`,
+			expectedRows: [][]interface{}{
+				{"0-0 0 -0:30:0", "-0-1 0 0:0:0", "0-0 0 -0:30:0", "1-2 -3 -18:1:55", "0-0 0 1:0:0"},
+			},
+		},
 		{
 			name: "interval from sub operator",
 			query: `
@@ -4962,6 +5895,22 @@ SELECT
 			query:        `SELECT MAKE_INTERVAL(1, 6, 15), MAKE_INTERVAL(hour => 10, second => 20), MAKE_INTERVAL(1, minute => 5, day => 2)`,
 			expectedRows: [][]interface{}{{"1-6 15 0:0:0", "0-0 0 10:0:20", "1-0 2 0:5:0"}},
 		},
+		{
+			name:         "make interval without arguments",
+			query:        `SELECT MAKE_INTERVAL(), MAKE_INTERVAL(day => NULL)`,
+			expectedRows: [][]interface{}{{"0-0 0 0:0:0", nil}},
+		},
+		{
+			name: "date math with make interval",
+			query: `
+SELECT
+  TIMESTAMP "2008-12-25 15:30:00+00" + MAKE_INTERVAL(day => 3),
+  DATETIME "2008-12-25 15:30:00" + MAKE_INTERVAL(hour => 10, minute => 45),
+  DATETIME "2008-12-25 15:30:00" - MAKE_INTERVAL(month => 1, second => 30)`,
+			expectedRows: [][]interface{}{
+				{createTimestampFormatFromString("2008-12-28 15:30:00+00"), "2008-12-26T02:15:00", "2008-11-25T15:29:30"},
+			},
+		},
 		{
 			name: "extract from interval",
 			query: `SELECT
@@ -4973,6 +5922,29 @@ SELECT
 				{int64(1), int64(1), int64(370), int64(49), int64(2), int64(1), int64(0), int64(0)},
 			},
 		},
+		{
+			name: "extract from constructed interval",
+			query: `SELECT
+  EXTRACT(YEAR FROM i), EXTRACT(MONTH FROM i), EXTRACT(DAY FROM i),
+  EXTRACT(HOUR FROM i), EXTRACT(MINUTE FROM i), EXTRACT(SECOND FROM i)
+  FROM UNNEST([MAKE_INTERVAL(1, 6, 15, 10, 5, 20), MAKE_INTERVAL(day => -5, hour => -2)]) AS i`,
+			expectedRows: [][]interface{}{
+				{int64(1), int64(6), int64(15), int64(10), int64(5), int64(20)},
+				{int64(0), int64(0), int64(-5), int64(-2), int64(0), int64(0)},
+			},
+		},
+		{
+			name: "extract from non normalized interval",
+			query: `SELECT
+  EXTRACT(YEAR FROM MAKE_INTERVAL(month => 14)), EXTRACT(MONTH FROM MAKE_INTERVAL(month => 14)),
+  EXTRACT(HOUR FROM MAKE_INTERVAL(second => 3700)), EXTRACT(MINUTE FROM MAKE_INTERVAL(second => 3700)), EXTRACT(SECOND FROM MAKE_INTERVAL(second => 3700))`,
+			expectedRows: [][]interface{}{{int64(1), int64(2), int64(1), int64(1), int64(40)}},
+		},
+		{
+			name:         "extract from null interval",
+			query:        `SELECT EXTRACT(DAY FROM CAST(NULL AS INTERVAL))`,
+			expectedRows: [][]interface{}{{nil}},
+		},
 		{
 			name:         "justify_days",
 			query:        `SELECT JUSTIFY_DAYS(INTERVAL 29 DAY), JUSTIFY_DAYS(INTERVAL -30 DAY), JUSTIFY_DAYS(INTERVAL 31 DAY), JUSTIFY_DAYS(INTERVAL -65 DAY), JUSTIFY_DAYS(INTERVAL 370 DAY)`,
@@ -4988,6 +5960,28 @@ SELECT
 			query:        `SELECT JUSTIFY_INTERVAL(INTERVAL '29 49:00:00' DAY TO SECOND)`,
 			expectedRows: [][]interface{}{{"0-1 1 1:0:0"}},
 		},
+		{
+			name:         "justify_days over multiple years",
+			query:        `SELECT JUSTIFY_DAYS(INTERVAL 800 DAY), JUSTIFY_DAYS(INTERVAL -800 DAY)`,
+			expectedRows: [][]interface{}{{"2-2 20 0:0:0", "-2-2 -20 0:0:0"}},
+		},
+		{
+			name:         "justify_hours with seconds",
+			query:        `SELECT JUSTIFY_HOURS(MAKE_INTERVAL(second => 90061)), JUSTIFY_INTERVAL(MAKE_INTERVAL(day => 59, hour => 30))`,
+			expectedRows: [][]interface{}{{"0-0 1 1:1:1", "0-2 0 6:0:0"}},
+		},
+		{
+			name: "extract from justified interval",
+			query: `
+SELECT
+  EXTRACT(DAY FROM INTERVAL 45 DAY),
+  EXTRACT(MONTH FROM JUSTIFY_DAYS(INTERVAL 45 DAY)),
+  EXTRACT(DAY FROM JUSTIFY_DAYS(INTERVAL 45 DAY)),
+  EXTRACT(DAY FROM JUSTIFY_HOURS(INTERVAL 50 HOUR)),
+  EXTRACT(HOUR FROM JUSTIFY_HOURS(INTERVAL 50 HOUR)),
+  EXTRACT(DAY FROM JUSTIFY_INTERVAL(INTERVAL '29 49:00:00' DAY TO SECOND))`,
+			expectedRows: [][]interface{}{{int64(45), int64(1), int64(15), int64(2), int64(2), int64(1)}},
+		},
 
 		// numeric/bignumeric
 		{
@@ -4995,6 +5989,16 @@ SELECT
 			query:        `SELECT cast('12.4E17' as NUMERIC) numeric, cast('12.4E37' as BIGNUMERIC) bignumeric`,
 			expectedRows: [][]interface{}{{"1240000000000000000", "124000000000000000000000000000000000000"}},
 		},
+		{
+			name:         "cast float64 to numeric",
+			query:        `SELECT CAST(0.1 AS NUMERIC), CAST(1.0 / 3.0 AS NUMERIC), CAST(-2.0 / 3.0 AS NUMERIC), CAST(1.0 / 3.0 AS BIGNUMERIC)`,
+			expectedRows: [][]interface{}{{"0.1", "0.333333333", "-0.666666667", "0.33333333333333331482961625624739099294"}},
+		},
+		{
+			name:         "cast float64 column to numeric",
+			query:        `SELECT CAST(x AS NUMERIC), CAST(x AS NUMERIC) = NUMERIC '0.1', CAST(x * 3 AS NUMERIC) FROM UNNEST([0.1]) AS x`,
+			expectedRows: [][]interface{}{{"0.1", true, "0.3"}},
+		},
 		{
 			name:         "parse_numeric",
 			query:        `SELECT PARSE_NUMERIC("123.45"), PARSE_NUMERIC("12.34E27"), PARSE_NUMERIC("1.0123456789")`,
@@ -5005,6 +6009,38 @@ SELECT
 			query:        `SELECT PARSE_BIGNUMERIC("123.45"), PARSE_BIGNUMERIC("123.456E37"), PARSE_BIGNUMERIC("1.123456789012345678901234567890123456789")`,
 			expectedRows: [][]interface{}{{"123.45", "1234560000000000000000000000000000000000", "1.12345678901234567890123456789012345679"}},
 		},
+		{
+			name: "parse_numeric with sign and whitespace",
+			query: `SELECT
+  PARSE_NUMERIC("  -  12.34 "), PARSE_NUMERIC("+1.5e2"), PARSE_NUMERIC(".5"), PARSE_NUMERIC("0.0000000005"),
+  PARSE_NUMERIC("-0.0000000005"), PARSE_NUMERIC("1e-2000"), PARSE_NUMERIC(NULL)`,
+			expectedRows: [][]interface{}{{"-12.34", "150", "0.5", "0.000000001", "-0.000000001", "0", nil}},
+		},
+		{
+			name:         "parse_numeric with maximum value",
+			query:        `SELECT PARSE_NUMERIC("99999999999999999999999999999.999999999"), PARSE_BIGNUMERIC("-578960446186580977117854925043439539266.34992332820282019728792003956564819968")`,
+			expectedRows: [][]interface{}{{"99999999999999999999999999999.999999999", "-578960446186580977117854925043439539266.34992332820282019728792003956564819968"}},
+		},
+		{
+			name:        "parse_numeric overflow",
+			query:       `SELECT PARSE_NUMERIC("99999999999999999999999999999.9999999995")`,
+			expectedErr: "PARSE_NUMERIC: numeric value 99999999999999999999999999999.9999999995 is out of range",
+		},
+		{
+			name:        "parse_numeric overflow with exponent",
+			query:       `SELECT PARSE_NUMERIC("1e29")`,
+			expectedErr: "PARSE_NUMERIC: numeric value 1e29 is out of range",
+		},
+		{
+			name:        "parse_bignumeric overflow",
+			query:       `SELECT PARSE_BIGNUMERIC("-1e39")`,
+			expectedErr: "PARSE_BIGNUMERIC: numeric value -1e39 is out of range",
+		},
+		{
+			name:        "parse_numeric invalid literal",
+			query:       `SELECT PARSE_NUMERIC("1/2")`,
+			expectedErr: "PARSE_NUMERIC: unexpected numeric literal: 1/2",
+		},
 		{
 			name:         "cast numeric and bignumeric to string",
 			query:        `SELECT cast(PARSE_NUMERIC("123.456") as STRING), cast(PARSE_BIGNUMERIC("123.456") as STRING)`,
@@ -5101,6 +6137,19 @@ FROM
     AS json_value`,
 			expectedRows: [][]interface{}{{`"Jane"`}, {nil}, {`"John"`}},
 		},
+		{
+			name: "json value member and subscript access",
+			query: `
+SELECT
+  json_value.a.b,
+  json_value['a']['b'],
+  json_value.arr[1],
+  json_value.arr[5],
+  json_value.arr[-1],
+  json_value.missing
+FROM UNNEST([JSON '{"a": {"b": [1, 2]}, "arr": ["x", {"y": true}]}']) AS json_value`,
+			expectedRows: [][]interface{}{{`[1,2]`, `[1,2]`, `{"y":true}`, nil, nil, nil}},
+		},
 		{
 			name:         "json_extract",
 			query:        `SELECT JSON_EXTRACT(JSON '{"class":{"students":[{"id":5},{"id":12}]}}', '$.class')`,
@@ -5229,6 +6278,31 @@ SELECT
   JSON_QUERY(JSON '{"a":null}', "$.b")`,
 			expectedRows: [][]interface{}{{nil, nil, nil, nil}},
 		},
+		{
+			name: "json_extract and json_query with root array index",
+			query: `
+SELECT
+  JSON_EXTRACT('[{"a":1},{"a":2}]', '$[1]'),
+  JSON_EXTRACT('[{"a":1},{"a":2}]', '$[1].a'),
+  JSON_QUERY('[{"a":1},{"a":2}]', '$[0]'),
+  JSON_QUERY('[{"a":1},{"a":2}]', '$[2]')`,
+			expectedRows: [][]interface{}{{`{"a":2}`, `2`, `{"a":1}`, nil}},
+		},
+		{
+			name:         "json_extract with single quote path selector",
+			query:        `SELECT JSON_EXTRACT('{"a.b":{"c":1}}', "$['a.b']"), JSON_EXTRACT('{"a.b":{"c":1}}', "$['a.b'].c")`,
+			expectedRows: [][]interface{}{{`{"c":1}`, `1`}},
+		},
+		{
+			name:        "json_extract with double quote path selector",
+			query:       `SELECT JSON_EXTRACT('{"a.b":1}', '$."a.b"')`,
+			expectedErr: "JSON_EXTRACT: doesn't use double quote path selector",
+		},
+		{
+			name:        "json_query with single quote path selector",
+			query:       `SELECT JSON_QUERY('{"a.b":1}', "$['a.b']")`,
+			expectedErr: "JSON_QUERY: doesn't use single quote path selector",
+		},
 		{
 			name:         "json_extract_scalar with number",
 			query:        `SELECT JSON_EXTRACT_SCALAR(JSON '{ "name" : "Jakob", "age" : "6" }', '$.age')`,
@@ -5249,6 +6323,16 @@ SELECT
 			query:        `SELECT JSON_EXTRACT_SCALAR('{"a.b": {"c": "world"}}', "$['a.b'].c")`,
 			expectedRows: [][]interface{}{{"world"}},
 		},
+		{
+			name:         "json_extract_scalar with object and null",
+			query:        `SELECT JSON_EXTRACT_SCALAR('{"a": {"b": 1}, "c": null, "d": true}', '$.a'), JSON_EXTRACT_SCALAR('{"a": {"b": 1}, "c": null, "d": true}', '$.c'), JSON_EXTRACT_SCALAR('{"a": {"b": 1}, "c": null, "d": true}', '$.d')`,
+			expectedRows: [][]interface{}{{nil, nil, "true"}},
+		},
+		{
+			name:         "json_value with object and null",
+			query:        `SELECT JSON_VALUE('{"a": {"b": 1}, "c": null, "d": true}', '$.a'), JSON_VALUE('{"a": {"b": 1}, "c": null, "d": true}', '$.c'), JSON_VALUE('{"a": {"b": 1}, "c": null, "d": true}', '$.d')`,
+			expectedRows: [][]interface{}{{nil, nil, "true"}},
+		},
 		{
 			name:         "json_value with number",
 			query:        `SELECT JSON_VALUE(JSON '{ "name" : "Jakob", "age" : "6" }', '$.age')`,
@@ -5319,6 +6403,11 @@ SELECT
 			query:        `SELECT JSON_EXTRACT_ARRAY('{"a":"foo"}','$.a'), JSON_EXTRACT_ARRAY('{"a":"foo"}','$.b'), JSON_EXTRACT_ARRAY(JSON 'null', '$')`,
 			expectedRows: [][]interface{}{{nil, nil, nil}},
 		},
+		{
+			name:         "json_extract_array with object",
+			query:        `SELECT JSON_EXTRACT_ARRAY('{"a":{"b":[1,2]}}','$.a'), JSON_EXTRACT_ARRAY('{"a":{"b":[1,2]}}','$.a.b')`,
+			expectedRows: [][]interface{}{{nil, []interface{}{"1", "2"}}},
+		},
 		{
 			name:         "json_extract_array with empty array",
 			query:        `SELECT JSON_EXTRACT_ARRAY('{"a":"foo","b":[]}','$.b')`,
@@ -5331,6 +6420,25 @@ SELECT
 				{[]interface{}{`"apples"`, `"oranges"`, `"grapes"`}},
 			},
 		},
+		{
+			name: "json path with recursive descent",
+			query: `SELECT
+  JSON_QUERY_ARRAY('{"id":1,"a":{"id":2,"b":[{"id":3},{"name":"x"}]}}', '$..id'),
+  JSON_EXTRACT('{"id":1,"a":{"id":2}}', '$..id'),
+  JSON_EXTRACT_ARRAY('{"a":{"b":1}}', '$..c')`,
+			expectedRows: [][]interface{}{
+				{[]interface{}{"1", "2", "3"}, "[1,2]", []interface{}{}},
+			},
+		},
+		{
+			name: "json path with array wildcard",
+			query: `SELECT
+  JSON_QUERY_ARRAY('{"fruits":[{"name":"apple"},{"color":"red"},{"name":"kiwi"}]}', '$.fruits[*].name'),
+  JSON_QUERY(JSON '{"a":[1,{"b":2},[3]]}', '$.a[*]')`,
+			expectedRows: [][]interface{}{
+				{[]interface{}{`"apple"`, `"kiwi"`}, `[1,{"b":2},[3]]`},
+			},
+		},
 		{
 			name:  "json_query_array with integer",
 			query: `SELECT JSON_QUERY_ARRAY('[1,2,3]')`,
@@ -5369,6 +6477,11 @@ SELECT
 			query:        `SELECT JSON_QUERY_ARRAY('{"a":"foo"}','$.a'), JSON_QUERY_ARRAY('{"a":"foo"}','$.b'), JSON_QUERY_ARRAY(JSON 'null', '$')`,
 			expectedRows: [][]interface{}{{nil, nil, nil}},
 		},
+		{
+			name:         "json_query_array with object",
+			query:        `SELECT JSON_QUERY_ARRAY('{"a":{"b":[1,2]}}','$.a'), JSON_QUERY_ARRAY('{"a":{"b":[1,2]}}','$.a.b')`,
+			expectedRows: [][]interface{}{{nil, []interface{}{"1", "2"}}},
+		},
 		{
 			name:         "json_query_array with empty array",
 			query:        `SELECT JSON_QUERY_ARRAY('{"a":"foo","b":[]}','$.b')`,
@@ -5415,6 +6528,13 @@ SELECT
   JSON_EXTRACT_STRING_ARRAY(JSON 'null', '$')`,
 			expectedRows: [][]interface{}{{nil, nil, nil, nil, nil, nil, nil}},
 		},
+		{
+			name:  "json_extract_string_array with null element",
+			query: `SELECT JSON_EXTRACT_STRING_ARRAY('{"a":["foo",null,true,1.5]}','$.a')`,
+			expectedRows: [][]interface{}{
+				{[]interface{}{"foo", nil, "true", "1.5"}},
+			},
+		},
 		{
 			name:         "json_extract_string_array with empty array",
 			query:        `SELECT JSON_EXTRACT_STRING_ARRAY('{"a":"foo","b":[]}','$.b')`,
@@ -5461,6 +6581,13 @@ SELECT
   JSON_VALUE_ARRAY(JSON 'null', '$')`,
 			expectedRows: [][]interface{}{{nil, nil, nil, nil, nil, nil, nil}},
 		},
+		{
+			name:  "json_value_array with null element",
+			query: `SELECT JSON_VALUE_ARRAY('{"a":["foo",null,true,1.5]}','$.a')`,
+			expectedRows: [][]interface{}{
+				{[]interface{}{"foo", nil, "true", "1.5"}},
+			},
+		},
 		{
 			name:         "json_value_array with empty array",
 			query:        `SELECT JSON_VALUE_ARRAY('{"a":"foo","b":[]}','$.b')`,
@@ -5471,6 +6598,36 @@ SELECT
 			query:        `SELECT PARSE_JSON('{"coordinates":[10,20],"id":1}')`,
 			expectedRows: [][]interface{}{{`{"coordinates":[10,20],"id":1}`}},
 		},
+		{
+			name:         "parse_json with round mode",
+			query:        `SELECT PARSE_JSON('{"id":922337203685477580701}', wide_number_mode=>'round'), PARSE_JSON('[1.5,2]', wide_number_mode=>'round')`,
+			expectedRows: [][]interface{}{{`{"id":9.223372036854776e+20}`, `[1.5,2]`}},
+		},
+		{
+			name:         "parse_json with round mode keeps key order",
+			query:        `SELECT PARSE_JSON('{"b":922337203685477580701,"a":[1,"922337203685477580701"]}', wide_number_mode=>'round')`,
+			expectedRows: [][]interface{}{{`{"b":9.223372036854776e+20,"a":[1,"922337203685477580701"]}`}},
+		},
+		{
+			name:         "parse_json with exact mode and decimal",
+			query:        `SELECT PARSE_JSON('{"v":0.1}', wide_number_mode=>'exact')`,
+			expectedRows: [][]interface{}{{`{"v":0.1}`}},
+		},
+		{
+			name:        "parse_json with exact mode",
+			query:       `SELECT PARSE_JSON('{"id":922337203685477580701}', wide_number_mode=>'exact')`,
+			expectedErr: "PARSE_JSON: cannot convert 922337203685477580701 without loss of precision in exact mode",
+		},
+		{
+			name:        "parse_json with malformed json",
+			query:       `SELECT PARSE_JSON('{"a" 1}')`,
+			expectedErr: `PARSE_JSON: invalid JSON string "{\"a\" 1}": expected colon after object key`,
+		},
+		{
+			name:         "json and string conversion",
+			query:        `SELECT TO_JSON_STRING(JSON '{"a":[1,"x"]}'), STRING(JSON '"abc"'), PARSE_JSON('{"a":[1,"x"]}')`,
+			expectedRows: [][]interface{}{{`{"a":[1,"x"]}`, "abc", `{"a":[1,"x"]}`}},
+		},
 
 		{
 			name: "to_json",
@@ -5491,6 +6648,11 @@ SELECT TO_JSON(t) AS json_objects FROM CoordinatesTable AS t`,
 			query:        `SELECT TO_JSON(STRUCT("foo" AS a, TO_JSON(STRUCT("bar" AS c)) AS b))`,
 			expectedRows: [][]interface{}{{`{"a":"foo","b":{"c":"bar"}}`}},
 		},
+		{
+			name:         "to_json with anonymous struct field",
+			query:        `SELECT TO_JSON(STRUCT(1 AS a, 2)), TO_JSON_STRING(STRUCT(1, STRUCT('x')))`,
+			expectedRows: [][]interface{}{{`{"a":1,"_field_2":2}`, `{"_field_1":1,"_field_2":{"_field_1":"x"}}`}},
+		},
 		{
 			name: "to_json_string",
 			query: `
@@ -5526,6 +6688,36 @@ FROM CoordinatesTable AS t`,
 			query:        `SELECT FLOAT64(JSON '9.8') AS velocity`,
 			expectedRows: [][]interface{}{{float64(9.8)}},
 		},
+		{
+			name:         "json accessors with integral and null values",
+			query:        `SELECT INT64(JSON '10.0'), INT64(JSON '1e2'), FLOAT64(JSON '9007199254740993', wide_number_mode=>'round'), BOOL(JSON 'false'), STRING(CAST(NULL AS JSON))`,
+			expectedRows: [][]interface{}{{int64(10), int64(100), float64(9007199254740992), false, nil}},
+		},
+		{
+			name:        "json_int64 with string",
+			query:       `SELECT INT64(JSON '"abc"')`,
+			expectedErr: `INT64: the provided JSON input is not an integer: "abc"`,
+		},
+		{
+			name:        "json_int64 with fraction",
+			query:       `SELECT INT64(JSON '10.5')`,
+			expectedErr: "INT64: the provided JSON number cannot be converted to an integer without loss: 10.5",
+		},
+		{
+			name:        "json_bool with number",
+			query:       `SELECT BOOL(JSON '1')`,
+			expectedErr: "BOOL: the provided JSON input is not a boolean: 1",
+		},
+		{
+			name:        "json_string with number",
+			query:       `SELECT STRING(JSON '2005')`,
+			expectedErr: "STRING: the provided JSON input is not a string: 2005",
+		},
+		{
+			name:        "json_float64 with exact mode",
+			query:       `SELECT FLOAT64(JSON '9007199254740993', wide_number_mode=>'exact')`,
+			expectedErr: "FLOAT64: cannot convert 9007199254740993 without loss of precision in exact mode",
+		},
 		{
 			name: "json_type",
 			query: `
@@ -5655,6 +6847,24 @@ FROM (
 				{"amazon.co.uk", "co.uk", "amazon.co.uk"},
 			},
 		},
+		{
+			name: "net_host with null or unparseable url",
+			query: `
+SELECT
+  NET.HOST(url),
+  NET.PUBLIC_SUFFIX(url),
+  NET.REG_DOMAIN(url)
+FROM (
+  SELECT CAST(NULL AS STRING) AS url UNION ALL
+  SELECT "http://[::1" UNION ALL
+  SELECT "http://%zz.com"
+)`,
+			expectedRows: [][]interface{}{
+				{nil, nil, nil},
+				{nil, nil, nil},
+				{nil, nil, nil},
+			},
+		},
 		{
 			name: "net_ip_from_string",
 			query: `
@@ -5672,9 +6882,19 @@ FROM (
 				{`b"\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x01"`},
 				{`b"0123456789@ABCDE"`},
 				{`b"\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\xff\xff\xc0\x00\x02\x80"`},
-				{nil},
+				{"NULL"},
 			},
 		},
+		{
+			name:        "net_ip_from_string with invalid address",
+			query:       `SELECT NET.IP_FROM_STRING("48.49.50")`,
+			expectedErr: "NET.IP_FROM_STRING: invalid ip address 48.49.50",
+		},
+		{
+			name:        "net_ip_from_string with zone",
+			query:       `SELECT NET.IP_FROM_STRING("fe80::1%eth0")`,
+			expectedErr: "NET.IP_FROM_STRING: invalid ip address fe80::1%eth0",
+		},
 		{
 			name: "net_ip_net_mask",
 			query: `
@@ -5721,6 +6941,11 @@ FROM (
 				{"::ffff:192.0.2.128"},
 			},
 		},
+		{
+			name:        "net_ip_to_string with invalid length",
+			query:       `SELECT NET.IP_TO_STRING(b"012")`,
+			expectedErr: "NET.IP_TO_STRING: invalid byte array",
+		},
 		{
 			name: "net_ip_trunc",
 			query: `
@@ -5773,6 +6998,16 @@ FROM (
 				{`b"\xff\xff\xff\xfe"`},
 			},
 		},
+		{
+			name:        "net_ipv4_from_int64 with too large value",
+			query:       `SELECT NET.IPV4_FROM_INT64(4294967296)`,
+			expectedErr: "NET.IPV4_FROM_INT64: 4294967296 is out of range for ipv4 address",
+		},
+		{
+			name:        "net_ipv4_from_int64 with too small value",
+			query:       `SELECT NET.IPV4_FROM_INT64(-2147483649)`,
+			expectedErr: "NET.IPV4_FROM_INT64: -2147483649 is out of range for ipv4 address",
+		},
 		{
 			name: "net_ipv4_to_int64",
 			query: `
@@ -5789,6 +7024,11 @@ FROM (
 				{"0xFFFFFFFF"},
 			},
 		},
+		{
+			name:        "net_ipv4_to_int64 with ipv6 address",
+			query:       `SELECT NET.IPV4_TO_INT64(NET.IP_FROM_STRING("::1"))`,
+			expectedErr: "NET.IPV4_TO_INT64: length of bytes array must be 4",
+		},
 		{
 			name: "net_safe_if_from_string",
 			query: `
@@ -5808,12 +7048,96 @@ FROM (
 				{`b"\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x01"`},
 				{`b"0123456789@ABCDE"`},
 				{`b"\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\xff\xff\xc0\x00\x02\x80"`},
-				{nil},
-				{nil},
-				{nil},
+				{"NULL"},
+				{"NULL"},
+				{"NULL"},
 			},
 		},
 
+		// geography
+		{
+			name:         "st_geogpoint",
+			query:        `SELECT ST_GEOGPOINT(-122.0838, 37.386), ST_GEOGPOINT(190, -10.5), ST_GEOGPOINT(NULL, 1)`,
+			expectedRows: [][]interface{}{{"POINT(-122.0838 37.386)", "POINT(-170 -10.5)", nil}},
+		},
+		{
+			name:         "st_geogpoint round trip",
+			query:        `WITH points AS (SELECT ST_GEOGPOINT(x, x / 2) AS p FROM UNNEST([1.5, 3.0]) AS x) SELECT p, FORMAT('%t', p) FROM points`,
+			expectedRows: [][]interface{}{{"POINT(1.5 0.75)", "POINT(1.5 0.75)"}, {"POINT(3 1.5)", "POINT(3 1.5)"}},
+		},
+		{
+			name:        "st_geogpoint with invalid latitude",
+			query:       `SELECT ST_GEOGPOINT(0, 91)`,
+			expectedErr: "ST_GEOGPOINT: latitude must be in the range [-90, 90]: 91",
+		},
+		{
+			name: "st_geogfromtext",
+			query: `SELECT ST_ASTEXT(ST_GEOGFROMTEXT('POINT(1 2)')),
+ ST_ASTEXT(ST_GEOGFROMTEXT('linestring (0 0, 1.5 1, 2 -3)')),
+ ST_ASTEXT(ST_GEOGFROMTEXT('POLYGON((0 0, 2 0, 2 2, 0 0), (0.5 0.5, 1 0.5, 1 1, 0.5 0.5))')),
+ ST_ASTEXT(ST_GEOGFROMTEXT('POINT EMPTY')),
+ ST_ASTEXT(ST_GEOGFROMTEXT(NULL))`,
+			expectedRows: [][]interface{}{{
+				"POINT(1 2)",
+				"LINESTRING(0 0, 1.5 1, 2 -3)",
+				"POLYGON((0 0, 2 0, 2 2, 0 0), (0.5 0.5, 1 0.5, 1 1, 0.5 0.5))",
+				"GEOMETRYCOLLECTION EMPTY",
+				nil,
+			}},
+		},
+		{
+			name:         "st_geogfromtext round trip",
+			query:        `WITH geos AS (SELECT ST_GEOGFROMTEXT(wkt) AS g FROM UNNEST(['POINT(1 2)', 'LINESTRING(0 0, 1 1)']) AS wkt) SELECT g, ST_ASTEXT(g) FROM geos`,
+			expectedRows: [][]interface{}{{"POINT(1 2)", "POINT(1 2)"}, {"LINESTRING(0 0, 1 1)", "LINESTRING(0 0, 1 1)"}},
+		},
+		{
+			name:        "st_geogfromtext with unclosed polygon",
+			query:       `SELECT ST_GEOGFROMTEXT('POLYGON((0 0, 2 0, 2 2, 0 1))')`,
+			expectedErr: `ST_GEOGFROMTEXT: failed to parse WKT "POLYGON((0 0, 2 0, 2 2, 0 1))": POLYGON ring must be closed`,
+		},
+		{
+			name:        "st_geogfromtext with malformed wkt",
+			query:       `SELECT ST_GEOGFROMTEXT('POINT(1 2')`,
+			expectedErr: `ST_GEOGFROMTEXT: failed to parse WKT "POINT(1 2": expected ')' at position 9`,
+		},
+		{
+			name:         "st_geogfromtext with oriented option",
+			query:        `SELECT ST_ASTEXT(ST_GEOGFROMTEXT('POINT(1 2)', FALSE))`,
+			expectedRows: [][]interface{}{{"POINT(1 2)"}},
+		},
+		{
+			name:        "st_geogfromtext with unsupported option",
+			query:       `SELECT ST_GEOGFROMTEXT('POINT(1 2)', TRUE)`,
+			expectedErr: `ST_GEOGFROMTEXT: oriented=TRUE is not supported`,
+		},
+		{
+			name:        "st_geogfromtext with unsupported type",
+			query:       `SELECT ST_GEOGFROMTEXT('MULTIPOINT(1 2, 3 4)')`,
+			expectedErr: `ST_GEOGFROMTEXT: failed to parse WKT "MULTIPOINT(1 2, 3 4)": unsupported geometry type MULTIPOINT`,
+		},
+		{
+			name: "st_distance",
+			query: `SELECT ROUND(ST_DISTANCE(ST_GEOGPOINT(0, 0), ST_GEOGPOINT(0, 1))),
+ ROUND(ST_DISTANCE(ST_GEOGPOINT(179, 0), ST_GEOGPOINT(-179, 0))),
+ ST_DISTANCE(ST_GEOGPOINT(1, 2), ST_GEOGPOINT(1, 2)),
+ ST_DISTANCE(ST_GEOGPOINT(1, 2), NULL),
+ ST_DISTANCE(ST_GEOGFROMTEXT('POINT EMPTY'), ST_GEOGPOINT(1, 2))`,
+			expectedRows: [][]interface{}{{float64(111195), float64(222390), float64(0), nil, nil}},
+		},
+		{
+			name: "st_dwithin",
+			query: `SELECT ST_DWITHIN(ST_GEOGPOINT(-122.4194, 37.7749), ST_GEOGPOINT(-118.2437, 34.0522), 560000),
+ ST_DWITHIN(ST_GEOGPOINT(-122.4194, 37.7749), ST_GEOGPOINT(-118.2437, 34.0522), 550000),
+ ST_DWITHIN(ST_GEOGPOINT(1, 2), ST_GEOGPOINT(1, 2), 0),
+ ST_DWITHIN(ST_GEOGPOINT(1, 2), ST_GEOGPOINT(1, 2), NULL)`,
+			expectedRows: [][]interface{}{{true, false, true, nil}},
+		},
+		{
+			name:        "st_distance between linestrings",
+			query:       `SELECT ST_DISTANCE(ST_GEOGFROMTEXT('LINESTRING(0 0, 1 1)'), ST_GEOGPOINT(0, 0))`,
+			expectedErr: "ST_DISTANCE: only the distance between points is supported currently",
+		},
+
 		{
 			name: "single statement with named params",
 			query: `